@@ -0,0 +1,56 @@
+package stripe
+
+import (
+	"github.com/stripe/stripe-go/v72"
+)
+
+// expandAddress builds the address params from either an address map or a single address block,
+// keys other than the address fields are ignored so that e.g. the shipping map can be passed as is.
+func expandAddress(raw interface{}) *stripe.AddressParams {
+	address := &stripe.AddressParams{}
+	for k, v := range ToMap(raw) {
+		value := stripe.String(ToString(v))
+		switch k {
+		case "line1":
+			address.Line1 = value
+		case "line2":
+			address.Line2 = value
+		case "city":
+			address.City = value
+		case "state":
+			address.State = value
+		case "postal_code":
+			address.PostalCode = value
+		case "country":
+			address.Country = value
+		}
+	}
+	return address
+}
+
+// flattenAddressMap turns the address into an address map, only the fields set are included.
+func flattenAddressMap(address *stripe.Address) map[string]interface{} {
+	addressMap := make(map[string]interface{})
+	if address == nil {
+		return addressMap
+	}
+	if address.Line1 != "" {
+		addressMap["line1"] = address.Line1
+	}
+	if address.Line2 != "" {
+		addressMap["line2"] = address.Line2
+	}
+	if address.City != "" {
+		addressMap["city"] = address.City
+	}
+	if address.State != "" {
+		addressMap["state"] = address.State
+	}
+	if address.PostalCode != "" {
+		addressMap["postal_code"] = address.PostalCode
+	}
+	if address.Country != "" {
+		addressMap["country"] = address.Country
+	}
+	return addressMap
+}
@@ -0,0 +1,79 @@
+package stripe
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stripe/stripe-go/v72"
+)
+
+func TestExpandAddress(t *testing.T) {
+	cases := []struct {
+		name     string
+		raw      interface{}
+		expected *stripe.AddressParams
+	}{
+		{"nil", nil, &stripe.AddressParams{}},
+		{"partial map", map[string]interface{}{"city": "Berlin", "country": "DE"}, &stripe.AddressParams{
+			City:    stripe.String("Berlin"),
+			Country: stripe.String("DE"),
+		}},
+		{"other keys ignored", map[string]interface{}{"name": "Jenny Rosen", "line1": "Unter den Linden 1"},
+			&stripe.AddressParams{Line1: stripe.String("Unter den Linden 1")}},
+		{"address block", []interface{}{map[string]interface{}{"postal_code": "10117"}}, &stripe.AddressParams{
+			PostalCode: stripe.String("10117"),
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if address := expandAddress(tc.raw); !reflect.DeepEqual(address, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, address)
+			}
+		})
+	}
+}
+
+func TestFlattenAddressMap(t *testing.T) {
+	cases := []struct {
+		name     string
+		address  *stripe.Address
+		expected map[string]interface{}
+	}{
+		{"nil", nil, map[string]interface{}{}},
+		{"empty", &stripe.Address{}, map[string]interface{}{}},
+		{"partial", &stripe.Address{City: "Berlin", Country: "DE"}, map[string]interface{}{
+			"city":    "Berlin",
+			"country": "DE",
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if addressMap := flattenAddressMap(tc.address); !reflect.DeepEqual(addressMap, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, addressMap)
+			}
+		})
+	}
+}
+
+// TestAddressRoundTrip checks that an address read from Stripe expands back to the params it was created with.
+func TestAddressRoundTrip(t *testing.T) {
+	address := &stripe.Address{
+		Line1:      "Unter den Linden 1",
+		Line2:      "3rd floor",
+		City:       "Berlin",
+		State:      "BE",
+		PostalCode: "10117",
+		Country:    "DE",
+	}
+	expected := &stripe.AddressParams{
+		Line1:      stripe.String(address.Line1),
+		Line2:      stripe.String(address.Line2),
+		City:       stripe.String(address.City),
+		State:      stripe.String(address.State),
+		PostalCode: stripe.String(address.PostalCode),
+		Country:    stripe.String(address.Country),
+	}
+	if params := expandAddress(flattenAddressMap(address)); !reflect.DeepEqual(params, expected) {
+		t.Errorf("expected %+v, got %+v", expected, params)
+	}
+}
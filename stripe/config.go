@@ -0,0 +1,80 @@
+package stripe
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// Config is the configured provider meta passed to all resources and data sources.
+type Config struct {
+	API             *client.API
+	DefaultMetadata map[string]string
+	// IgnoreMetadataKeys are metadata keys added outside of Terraform, they are left out of the state
+	IgnoreMetadataKeys map[string]bool
+	// MinimalReads skips the expands of attributes the resource doesn't set
+	MinimalReads bool
+}
+
+// expandOnRead reports whether a read should expand the object behind the attribute key.
+// Without minimal_reads it always does, so that changes made outside Terraform show up.
+// With minimal_reads it still does for an attribute missing from the state, which was never read,
+// e.g. after an import. The expanded attributes are ForceNew, left empty they'd plan a replacement.
+func (c *Config) expandOnRead(d *schema.ResourceData, key string) bool {
+	if !c.MinimalReads {
+		return true
+	}
+	if _, set := d.GetOk(key); set {
+		return true
+	}
+	// unlike GetOk, GetOkExists tells an attribute read back empty apart from a missing one
+	_, read := d.GetOkExists(key)
+	return !read
+}
+
+// withDefaultMetadata merges the provider default metadata into the metadata of a resource,
+// the keys set on the resource win.
+func (c *Config) withDefaultMetadata(metadata map[string]interface{}) map[string]string {
+	merged := make(map[string]string, len(c.DefaultMetadata)+len(metadata))
+	for k, v := range c.DefaultMetadata {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = ToString(v)
+	}
+	return merged
+}
+
+// expandMetadata adds the metadata of the resource, merged with the default metadata, to the params.
+// Stripe keeps keys missing from an update, so the keys removed since the last apply are unset explicitly,
+// on create there's nothing to remove.
+func (c *Config) expandMetadata(d *schema.ResourceData, key string, params *stripe.Params) {
+	oldMetadata, newMetadata := d.GetChange(key)
+	metadata := c.withDefaultMetadata(ToMap(newMetadata))
+	for k := range c.withDefaultMetadata(ToMap(oldMetadata)) {
+		if _, kept := metadata[k]; !kept {
+			params.AddMetadata(k, "")
+		}
+	}
+	for k, v := range metadata {
+		params.AddMetadata(k, v)
+	}
+}
+
+// withoutDefaultMetadata drops the default metadata and the ignored keys Stripe returns for an object,
+// unless the resource sets the key itself, so neither shows up as a diff.
+func (c *Config) withoutDefaultMetadata(metadata map[string]string, configured map[string]interface{}) map[string]string {
+	filtered := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		if _, set := configured[k]; !set {
+			if c.IgnoreMetadataKeys[k] {
+				continue
+			}
+			if defaultValue, isDefault := c.DefaultMetadata[k]; isDefault && defaultValue == v {
+				continue
+			}
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
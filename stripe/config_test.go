@@ -0,0 +1,196 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func TestConfigExpandMetadata(t *testing.T) {
+	cases := []struct {
+		name     string
+		defaults map[string]string
+		state    map[string]string
+		config   map[string]interface{}
+		expected map[string]string
+	}{
+		{
+			name:     "create adds all keys",
+			config:   map[string]interface{}{"team": "growth", "tier": "gold"},
+			expected: map[string]string{"team": "growth", "tier": "gold"},
+		},
+		{
+			name:     "create merges the defaults",
+			defaults: map[string]string{"managed_by": "terraform", "team": "platform"},
+			config:   map[string]interface{}{"team": "growth"},
+			expected: map[string]string{"managed_by": "terraform", "team": "growth"},
+		},
+		{
+			name:     "update sends the changed value",
+			state:    map[string]string{"team": "growth", "tier": "gold"},
+			config:   map[string]interface{}{"team": "growth", "tier": "platinum"},
+			expected: map[string]string{"team": "growth", "tier": "platinum"},
+		},
+		{
+			name:     "update unsets removed keys",
+			state:    map[string]string{"team": "growth", "tier": "gold"},
+			config:   map[string]interface{}{"team": "growth"},
+			expected: map[string]string{"team": "growth", "tier": ""},
+		},
+		{
+			name:     "update unsets all keys",
+			state:    map[string]string{"team": "growth"},
+			config:   map[string]interface{}{},
+			expected: map[string]string{"team": ""},
+		},
+		{
+			name:     "update falls back to the default of a removed key",
+			defaults: map[string]string{"managed_by": "terraform"},
+			state:    map[string]string{"managed_by": "team-a"},
+			config:   map[string]interface{}{},
+			expected: map[string]string{"managed_by": "terraform"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := testMetadataResourceData(t, tc.state, tc.config)
+			params := &stripe.Params{}
+			(&Config{DefaultMetadata: tc.defaults}).expandMetadata(d, "metadata", params)
+			if !reflect.DeepEqual(params.Metadata, tc.expected) {
+				t.Errorf("expected metadata %v, got %v", tc.expected, params.Metadata)
+			}
+		})
+	}
+}
+
+// TestConfigDefaultMetadataChanged follows a change of default_metadata through the refresh and the update
+// of a resource, Stripe still has the metadata written with the previous defaults.
+func TestConfigDefaultMetadataChanged(t *testing.T) {
+	cases := []struct {
+		name     string
+		stripe   map[string]string
+		defaults map[string]string
+		config   map[string]interface{}
+		diff     bool
+		expected map[string]string
+	}{
+		{
+			name:     "removed default key is unset",
+			stripe:   map[string]string{"managed_by": "terraform", "team": "growth"},
+			config:   map[string]interface{}{"team": "growth"},
+			diff:     true,
+			expected: map[string]string{"managed_by": "", "team": "growth"},
+		},
+		{
+			name:     "changed default value is sent",
+			stripe:   map[string]string{"managed_by": "terraform"},
+			defaults: map[string]string{"managed_by": "platform"},
+			config:   map[string]interface{}{},
+			diff:     true,
+			expected: map[string]string{"managed_by": "platform"},
+		},
+		{
+			name:     "added default key waits for the next metadata change",
+			stripe:   map[string]string{"team": "growth"},
+			defaults: map[string]string{"managed_by": "terraform"},
+			config:   map[string]interface{}{"team": "growth"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &Config{DefaultMetadata: tc.defaults}
+			state := config.withoutDefaultMetadata(tc.stripe, tc.config)
+
+			d := testMetadataResourceData(t, state, tc.config)
+			if d.HasChange("metadata") != tc.diff {
+				t.Fatalf("expected a metadata diff: %t, state %v", tc.diff, state)
+			}
+			if !tc.diff {
+				return
+			}
+			params := &stripe.Params{}
+			config.expandMetadata(d, "metadata", params)
+			if !reflect.DeepEqual(params.Metadata, tc.expected) {
+				t.Errorf("expected metadata %v, got %v", tc.expected, params.Metadata)
+			}
+		})
+	}
+}
+
+// testMetadataResourceData builds the data of a resource with only a metadata attribute, as seen by an update
+// from the metadata in the state to the configured one, or by a create when there's no state.
+func testMetadataResourceData(t *testing.T, state map[string]string, config map[string]interface{}) *schema.ResourceData {
+	sm := schema.InternalMap(map[string]*schema.Schema{
+		"metadata": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	})
+
+	var s *terraform.InstanceState
+	if state != nil {
+		s = &terraform.InstanceState{ID: "test", Attributes: map[string]string{"id": "test"}}
+		s.Attributes["metadata.%"] = strconv.Itoa(len(state))
+		for k, v := range state {
+			s.Attributes["metadata."+k] = v
+		}
+	}
+	diff, err := sm.Diff(context.Background(), s, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"metadata": config,
+	}), nil, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d, err := sm.Data(s, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return d
+}
+
+// TestConfigFromProvider checks the Config the provider arguments configure,
+// the coupon resource reads through its client with the configured key.
+func TestConfigFromProvider(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25}`)
+	}))
+	defer server.Close()
+
+	config := testProviderConfig(t, map[string]interface{}{
+		"api_key":              "sk_test_123",
+		"api_base_url":         server.URL,
+		"default_metadata":     map[string]interface{}{"managed_by": "terraform"},
+		"ignore_metadata_keys": []interface{}{"added_by_integration"},
+		"minimal_reads":        true,
+	})
+	if expected := map[string]string{"managed_by": "terraform"}; !reflect.DeepEqual(config.DefaultMetadata, expected) {
+		t.Errorf("expected default metadata %v, got %v", expected, config.DefaultMetadata)
+	}
+	if expected := map[string]bool{"added_by_integration": true}; !reflect.DeepEqual(config.IgnoreMetadataKeys, expected) {
+		t.Errorf("expected ignored metadata keys %v, got %v", expected, config.IgnoreMetadataKeys)
+	}
+	if !config.MinimalReads {
+		t.Errorf("expected minimal reads")
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{"percent_off": 25})
+	d.SetId("test")
+	if diags := resourceStripeCouponRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if authorization != "Bearer sk_test_123" {
+		t.Errorf("expected the configured API key, got Authorization %q", authorization)
+	}
+}
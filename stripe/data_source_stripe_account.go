@@ -0,0 +1,83 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeAccountRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The ID of a connected account to read. " +
+					"Defaults to the account the API key belongs to.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"country": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The country of the account.",
+			},
+			"default_currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code representing the default currency for the account.",
+			},
+			"charges_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account can create live charges.",
+			},
+			"payouts_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether Stripe can send payouts to this account.",
+			},
+			"business_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The business type, one of individual, company, non_profit or government_entity.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "An email address associated with the account.",
+			},
+		},
+	}
+}
+
+func dataSourceStripeAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	var account *stripe.Account
+	var err error
+	if accountID, set := d.GetOk("account_id"); set {
+		account, err = c.Account.GetByID(ToString(accountID), &stripe.AccountParams{Params: stripe.Params{Context: ctx}})
+	} else {
+		account, err = c.Account.Get()
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(account.ID)
+	return CallSet(
+		d.Set("country", account.Country),
+		d.Set("default_currency", account.DefaultCurrency),
+		d.Set("charges_enabled", account.ChargesEnabled),
+		d.Set("payouts_enabled", account.PayoutsEnabled),
+		d.Set("business_type", account.BusinessType),
+		d.Set("email", account.Email),
+	)
+}
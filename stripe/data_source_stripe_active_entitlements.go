@@ -0,0 +1,106 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/form"
+)
+
+// entitlements came after stripe-go v72, the list is paginated with the SDK iterator over raw calls
+type activeEntitlementListParams struct {
+	stripe.ListParams `form:"*"`
+	Customer          *string `form:"customer"`
+}
+
+type activeEntitlement struct {
+	ID        string `json:"id"`
+	Feature   string `json:"feature"`
+	LookupKey string `json:"lookup_key"`
+}
+
+type activeEntitlementList struct {
+	stripe.APIResource
+	stripe.ListMeta
+	Data []*activeEntitlement `json:"data"`
+}
+
+func dataSourceStripeActiveEntitlements() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeActiveEntitlementsRead,
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the customer to list the active entitlements of.",
+			},
+			"entitlements": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The features the customer is currently entitled to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the object.",
+						},
+						"feature": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the feature the customer is entitled to.",
+						},
+						"lookup_key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A unique key you provide as your own system identifier.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeActiveEntitlementsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	customer := ExtractString(d, "customer")
+	params := &activeEntitlementListParams{
+		Customer: stripe.String(customer),
+	}
+	// the largest page size Stripe allows keeps the number of requests low
+	params.Limit = stripe.Int64(100)
+
+	it := stripe.GetIter(params, func(p *stripe.Params, b *form.Values) ([]interface{}, stripe.ListContainer, error) {
+		list := &activeEntitlementList{}
+		p.Context = ctx
+		err := c.Customers.B.CallRaw(http.MethodGet, "/v1/entitlements/active_entitlements", c.Customers.Key, b, p, list)
+
+		ret := make([]interface{}, len(list.Data))
+		for i, v := range list.Data {
+			ret[i] = v
+		}
+		return ret, list, err
+	})
+
+	var entitlements []map[string]interface{}
+	for it.Next() {
+		entitlement := it.Current().(*activeEntitlement)
+		entitlements = append(entitlements, map[string]interface{}{
+			"id":         entitlement.ID,
+			"feature":    entitlement.Feature,
+			"lookup_key": entitlement.LookupKey,
+		})
+	}
+	if err := it.Err(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(customer)
+	return CallSet(
+		d.Set("entitlements", entitlements),
+	)
+}
@@ -0,0 +1,78 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeBalance() *schema.Resource {
+	balanceAmount := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"amount": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Balance amount in the smallest currency unit.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code, in lowercase.",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceStripeBalanceRead,
+		Schema: map[string]*schema.Schema{
+			"available": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     balanceAmount,
+				Description: "Funds that are available to be transferred or paid out, " +
+					"one element per currency.",
+			},
+			"pending": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     balanceAmount,
+				Description: "Funds that are not yet available in the balance, " +
+					"one element per currency.",
+			},
+			"livemode": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Has the value true if the balance exists in live mode or the value false if it exists in test mode.",
+			},
+		},
+	}
+}
+
+func flattenBalanceAmounts(amounts []*stripe.Amount) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, amount := range amounts {
+		flattened = append(flattened, map[string]interface{}{
+			"amount":   amount.Value,
+			"currency": amount.Currency,
+		})
+	}
+	return flattened
+}
+
+func dataSourceStripeBalanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	balance, err := c.Balance.Get(&stripe.BalanceParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the balance has no ID, there is exactly one per account
+	d.SetId("balance")
+	return CallSet(
+		d.Set("available", flattenBalanceAmounts(balance.Available)),
+		d.Set("pending", flattenBalanceAmounts(balance.Pending)),
+		d.Set("livemode", balance.Livemode),
+	)
+}
@@ -0,0 +1,104 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeCharge() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeChargeRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"amount": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Amount intended to be collected by this payment, in the smallest currency unit.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code, in lowercase.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the payment, either succeeded, pending, or failed.",
+			},
+			"paid": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the charge succeeded, or was successfully authorized for later capture.",
+			},
+			"refunded": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the charge has been fully refunded.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the customer this charge is for if one exists.",
+			},
+			"payment_intent": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the PaymentIntent associated with this charge, if one exists.",
+			},
+			"receipt_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the receipt for this charge.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs attached to the object.",
+			},
+		},
+	}
+}
+
+func dataSourceStripeChargeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	id := ExtractString(d, "id")
+	charge, err := c.Charges.Get(id, &stripe.ChargeParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		// a data source can't be dropped from the state, the configuration has to change
+		if isNotFound(err) {
+			return diag.Errorf("no charge found with id %q", id)
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId(charge.ID)
+	return CallSet(
+		d.Set("amount", charge.Amount),
+		d.Set("currency", charge.Currency),
+		d.Set("status", charge.Status),
+		d.Set("paid", charge.Paid),
+		d.Set("refunded", charge.Refunded),
+		func() error {
+			if charge.Customer != nil {
+				return d.Set("customer", charge.Customer.ID)
+			}
+			return nil
+		}(),
+		func() error {
+			if charge.PaymentIntent != nil {
+				return d.Set("payment_intent", charge.PaymentIntent.ID)
+			}
+			return nil
+		}(),
+		d.Set("receipt_url", charge.ReceiptURL),
+		d.Set("metadata", charge.Metadata),
+	)
+}
@@ -0,0 +1,169 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeCoupon() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeCouponRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "Unique identifier for the object.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description: "Name of the coupon displayed to customers on for instance invoices or receipts. " +
+					"The lookup requires exactly one coupon to match.",
+			},
+			"amount_off": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Amount (in the currency specified) that will be taken off the subtotal of any invoices " +
+					"for this customer.",
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "If amount_off has been set, " +
+					"the three-letter ISO code for the currency of the amount to take off.",
+			},
+			"percent_off": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+				Description: "Percent that will be taken off the subtotal of any invoices for this customer " +
+					"for the duration of the coupon.",
+			},
+			"duration": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "One of forever, once, and repeating. " +
+					"Describes how long a customer who applies this coupon will get the discount.",
+			},
+			"duration_in_months": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "If duration is repeating, the number of months the coupon applies.",
+			},
+			"max_redemptions": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Maximum number of times this coupon can be redeemed, " +
+					"in total, across all customers, before it is no longer valid.",
+			},
+			"redeem_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date after which the coupon can no longer be redeemed in the RFC3339 format.",
+			},
+			"times_redeemed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of times this coupon has been applied to a customer.",
+			},
+			"applies_to": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of product IDs this coupon applies to",
+			},
+			"applies_to_product_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the products this coupon applies to. " +
+					"Products which can't be retrieved anymore are left out.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs attached to the object.",
+			},
+			"valid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Taking account of the above properties, " +
+					"whether this coupon can still be applied to a customer.",
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Has the value true if the object exists in live mode " +
+					"or the value false if the object exists in test mode.",
+			},
+			"object": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "String representing the object’s type.",
+			},
+		},
+	}
+}
+
+func dataSourceStripeCouponRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	id := ExtractString(d, "id")
+	if id == "" {
+		found, err := findCouponByName(ctx, c, ExtractString(d, "name"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		id = found.ID
+	}
+
+	params := &stripe.CouponParams{}
+	params.Context = ctx
+	params.AddExpand("applies_to")
+	coupon, err := c.Coupons.Get(id, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var appliesTo []string
+	if coupon.AppliesTo != nil {
+		appliesTo = coupon.AppliesTo.Products
+	}
+
+	var appliesToProductNames []string
+	for _, productID := range appliesTo {
+		product, err := c.Products.Get(productID, &stripe.ProductParams{Params: stripe.Params{Context: ctx}})
+		if err != nil {
+			log.Printf("[WARN] Can't read product %s the coupon %s applies to: %s", productID, coupon.ID, err)
+			continue
+		}
+		appliesToProductNames = append(appliesToProductNames, product.Name)
+	}
+
+	d.SetId(coupon.ID)
+	return CallSet(
+		d.Set("name", coupon.Name),
+		d.Set("amount_off", coupon.AmountOff),
+		d.Set("currency", coupon.Currency),
+		d.Set("percent_off", coupon.PercentOff),
+		d.Set("duration", coupon.Duration),
+		d.Set("duration_in_months", coupon.DurationInMonths),
+		d.Set("max_redemptions", coupon.MaxRedemptions),
+		d.Set("redeem_by", ToRFC3339(coupon.RedeemBy)),
+		d.Set("times_redeemed", coupon.TimesRedeemed),
+		d.Set("applies_to", appliesTo),
+		d.Set("applies_to_product_names", appliesToProductNames),
+		d.Set("metadata", coupon.Metadata),
+		d.Set("valid", coupon.Valid),
+		d.Set("livemode", coupon.Livemode),
+		d.Set("object", coupon.Object),
+	)
+}
@@ -0,0 +1,119 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeCustomer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeCustomerRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "email"},
+				Description:  "Unique identifier for the object.",
+			},
+			"email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "email"},
+				Description:  "Customer’s email address. The lookup requires exactly one customer to match.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The customer’s full name or business name.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "An arbitrary string attached to the customer object.",
+			},
+			"phone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The customer’s phone number.",
+			},
+			"balance": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "An integer amount in cents that represents the customer’s current balance, " +
+					"which affect the customer’s future invoices.",
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Three-letter ISO code for the currency the customer can be charged in " +
+					"for recurring billing purposes.",
+			},
+			"created": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Time at which the object was created. Measured in seconds since the Unix epoch.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs attached to the object.",
+			},
+		},
+	}
+}
+
+func dataSourceStripeCustomerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	var customer *stripe.Customer
+	if id, set := d.GetOk("id"); set {
+		var err error
+		customer, err = c.Customers.Get(ToString(id), &stripe.CustomerParams{Params: stripe.Params{Context: ctx}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		email := ExtractString(d, "email")
+		params := &stripe.CustomerListParams{
+			Email: stripe.String(email),
+		}
+		params.Limit = stripe.Int64(2)
+		params.Context = ctx
+
+		var customers []*stripe.Customer
+		it := c.Customers.List(params)
+		for len(customers) < 2 && it.Next() {
+			customers = append(customers, it.Customer())
+		}
+		if err := it.Err(); err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(customers) {
+		case 0:
+			return diag.Errorf("no customer found with email %q", email)
+		case 1:
+			customer = customers[0]
+		default:
+			return diag.Errorf("multiple customers found with email %q, use id to select one", email)
+		}
+	}
+
+	d.SetId(customer.ID)
+	return CallSet(
+		d.Set("email", customer.Email),
+		d.Set("name", customer.Name),
+		d.Set("description", customer.Description),
+		d.Set("phone", customer.Phone),
+		d.Set("balance", customer.Balance),
+		d.Set("currency", customer.Currency),
+		d.Set("created", customer.Created),
+		d.Set("metadata", customer.Metadata),
+	)
+}
@@ -0,0 +1,140 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeInvoice() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeInvoiceRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the customer who will be billed.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the invoice, one of draft, open, paid, uncollectible, or void.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total after discounts and taxes.",
+			},
+			"amount_due": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Final amount due at this time for this invoice.",
+			},
+			"amount_paid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The amount, in the smallest currency unit, that was paid.",
+			},
+			"hosted_invoice_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The URL for the hosted invoice page, which allows customers to view and pay an invoice. " +
+					"Empty until the invoice is finalized.",
+			},
+			"invoice_pdf": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The link to download the PDF for the invoice. Empty until the invoice is finalized.",
+			},
+			"number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A unique, identifying string that appears on emails sent to the customer for this invoice.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code, in lowercase.",
+			},
+			"lines": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The individual line items that make up the invoice.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "An arbitrary string attached to the line item.",
+						},
+						"amount": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The amount, in the smallest currency unit.",
+						},
+						"quantity": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The quantity of the subscription, if the line item is a subscription or a proration.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeInvoiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	invoice, err := c.Invoices.Get(ExtractString(d, "id"), &stripe.InvoiceParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the invoice only embeds the first page of its lines
+	params := &stripe.InvoiceLineListParams{
+		ID: stripe.String(invoice.ID),
+	}
+	params.Limit = stripe.Int64(100)
+	params.Context = ctx
+
+	var lines []map[string]interface{}
+	it := c.Invoices.ListLines(params)
+	for it.Next() {
+		line := it.InvoiceLine()
+		lines = append(lines, map[string]interface{}{
+			"description": line.Description,
+			"amount":      line.Amount,
+			"quantity":    line.Quantity,
+		})
+	}
+	if err := it.Err(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(invoice.ID)
+	return CallSet(
+		func() error {
+			if invoice.Customer != nil {
+				return d.Set("customer", invoice.Customer.ID)
+			}
+			return nil
+		}(),
+		d.Set("status", invoice.Status),
+		d.Set("total", invoice.Total),
+		d.Set("amount_due", invoice.AmountDue),
+		d.Set("amount_paid", invoice.AmountPaid),
+		d.Set("hosted_invoice_url", invoice.HostedInvoiceURL),
+		d.Set("invoice_pdf", invoice.InvoicePDF),
+		d.Set("number", invoice.Number),
+		d.Set("currency", invoice.Currency),
+		d.Set("lines", lines),
+	)
+}
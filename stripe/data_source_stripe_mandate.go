@@ -0,0 +1,101 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeMandate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeMandateRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the mandate, which indicates whether it can be used to initiate a payment, " +
+					"one of active, inactive or pending.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the mandate, either multi_use or single_use.",
+			},
+			"payment_method": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the payment method associated with this mandate.",
+			},
+			"customer_acceptance": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Details about the customer’s acceptance of the mandate.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of customer acceptance information, either online or offline.",
+						},
+						"accepted_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time at which the customer accepted the mandate.",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address from which the mandate was accepted online.",
+						},
+						"user_agent": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user agent of the browser from which the mandate was accepted online.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeMandateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	mandate, err := c.Mandates.Get(ExtractString(d, "id"), &stripe.MandateParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(mandate.ID)
+	return CallSet(
+		d.Set("status", mandate.Status),
+		d.Set("type", mandate.Type),
+		func() error {
+			if mandate.PaymentMethod != nil {
+				return d.Set("payment_method", mandate.PaymentMethod.ID)
+			}
+			return nil
+		}(),
+		func() error {
+			if acceptance := mandate.CustomerAcceptance; acceptance != nil {
+				acceptanceMap := map[string]interface{}{
+					"type":        acceptance.Type,
+					"accepted_at": ToRFC3339(acceptance.AcceptedAt),
+				}
+				if acceptance.Online != nil {
+					acceptanceMap["ip_address"] = acceptance.Online.IPAddress
+					acceptanceMap["user_agent"] = acceptance.Online.UserAgent
+				}
+				return d.Set("customer_acceptance", []map[string]interface{}{acceptanceMap})
+			}
+			return nil
+		}(),
+	)
+}
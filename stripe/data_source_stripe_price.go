@@ -0,0 +1,146 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripePrice() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripePriceRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "lookup_key"},
+				Description:  "Unique identifier for the object.",
+			},
+			"lookup_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "lookup_key"},
+				Description:  "A lookup key used to retrieve prices dynamically from a static string.",
+			},
+			"unit_amount": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The unit amount in cents to be charged.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code, in lowercase.",
+			},
+			"product": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the product this price is associated with.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the price can be used for new purchases.",
+			},
+			"nickname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A brief description of the price, hidden from customers.",
+			},
+			"recurring": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The recurring components of a price such as interval and usage_type.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"interval": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Specifies billing frequency. Either day, week, month or year.",
+						},
+						"aggregate_usage": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Specifies a usage aggregation strategy for prices of usage_type=metered.",
+						},
+						"interval_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of intervals between subscription billings.",
+						},
+						"usage_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Configures how the quantity per period should be determined, metered or licensed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	var price *stripe.Price
+	if id, set := d.GetOk("id"); set {
+		var err error
+		price, err = c.Prices.Get(ToString(id), &stripe.PriceParams{Params: stripe.Params{Context: ctx}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		lookupKey := ExtractString(d, "lookup_key")
+		params := &stripe.PriceListParams{
+			LookupKeys: stripe.StringSlice([]string{lookupKey}),
+		}
+		params.Limit = stripe.Int64(2)
+		params.Context = ctx
+
+		var prices []*stripe.Price
+		it := c.Prices.List(params)
+		for len(prices) < 2 && it.Next() {
+			prices = append(prices, it.Price())
+		}
+		if err := it.Err(); err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(prices) {
+		case 0:
+			return diag.Errorf("no price found with lookup_key %q", lookupKey)
+		case 1:
+			price = prices[0]
+		default:
+			return diag.Errorf("multiple prices found with lookup_key %q, use id to select one", lookupKey)
+		}
+	}
+
+	d.SetId(price.ID)
+	return CallSet(
+		d.Set("lookup_key", price.LookupKey),
+		d.Set("unit_amount", price.UnitAmount),
+		d.Set("currency", price.Currency),
+		d.Set("product", price.Product.ID),
+		d.Set("active", price.Active),
+		d.Set("nickname", price.Nickname),
+		func() error {
+			if price.Recurring != nil {
+				return d.Set("recurring", []map[string]interface{}{
+					{
+						"interval":        price.Recurring.Interval,
+						"aggregate_usage": price.Recurring.AggregateUsage,
+						"interval_count":  price.Recurring.IntervalCount,
+						"usage_type":      price.Recurring.UsageType,
+					},
+				})
+			}
+			return nil
+		}(),
+	)
+}
@@ -0,0 +1,100 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeProducts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeProductsRead,
+		Schema: map[string]*schema.Schema{
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return products that are active or inactive. Returns both when not set.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only return products with the given IDs.",
+			},
+			"products": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The products matching the filters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the object.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The product’s name, meant to be displayable to the customer.",
+						},
+						"active": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the product is currently available for purchase.",
+						},
+						"metadata": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Set of key-value pairs attached to the object.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeProductsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.ProductListParams{}
+	// the largest page size Stripe allows keeps the number of requests low
+	params.Limit = stripe.Int64(100)
+
+	filters := []string{"products"}
+	if active, set := d.GetOkExists("active"); set {
+		params.Active = stripe.Bool(ToBool(active))
+		filters = append(filters, fmt.Sprintf("active=%t", ToBool(active)))
+	}
+	if ids, set := d.GetOk("ids"); set {
+		productIDs := ToStringSlice(ids)
+		params.IDs = stripe.StringSlice(productIDs)
+		filters = append(filters, "ids="+strings.Join(productIDs, ","))
+	}
+
+	// the iterator fetches one page at a time, only the fields exported here are kept around
+	var products []map[string]interface{}
+	params.Context = ctx
+	it := c.Products.List(params)
+	for it.Next() {
+		product := it.Product()
+		products = append(products, map[string]interface{}{
+			"id":       product.ID,
+			"name":     product.Name,
+			"active":   product.Active,
+			"metadata": product.Metadata,
+		})
+	}
+	if err := it.Err(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strings.Join(filters, ";"))
+	return CallSet(
+		d.Set("products", products),
+	)
+}
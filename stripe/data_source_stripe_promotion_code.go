@@ -0,0 +1,147 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripePromotionCode() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripePromotionCodeRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"code": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The customer-facing code. The lookup requires exactly one promotion code to match.",
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Only match promotion codes that are active or inactive. " +
+					"Matches both when not set.",
+			},
+			"coupon": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the coupon the promotion code belongs to.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The customer that this promotion code can be used by.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date at which the promotion code can no longer be redeemed, in RFC3339 format.",
+			},
+			"max_redemptions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum number of times this promotion code can be redeemed.",
+			},
+			"times_redeemed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of times this promotion code has been used.",
+			},
+			"restrictions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Settings that restrict the redemption of the promotion code.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"first_time_transaction": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the promotion code can only be redeemed by customers without any successful payments or invoices.",
+						},
+						"minimum_amount": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Minimum amount required to redeem this promotion code into a coupon.",
+						},
+						"minimum_amount_currency": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Three-letter ISO code for minimum_amount.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripePromotionCodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	code := ExtractString(d, "code")
+	params := &stripe.PromotionCodeListParams{
+		Code: stripe.String(code),
+	}
+	if active, set := d.GetOkExists("active"); set {
+		params.Active = stripe.Bool(ToBool(active))
+	}
+	params.Limit = stripe.Int64(2)
+	params.Context = ctx
+
+	var promotionCodes []*stripe.PromotionCode
+	it := c.PromotionCodes.List(params)
+	for len(promotionCodes) < 2 && it.Next() {
+		promotionCodes = append(promotionCodes, it.PromotionCode())
+	}
+	if err := it.Err(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var promotionCode *stripe.PromotionCode
+	switch len(promotionCodes) {
+	case 0:
+		return diag.Errorf("no promotion code found with code %q", code)
+	case 1:
+		promotionCode = promotionCodes[0]
+	default:
+		return diag.Errorf("multiple promotion codes found with code %q, use active to select one", code)
+	}
+
+	d.SetId(promotionCode.ID)
+	return CallSet(
+		d.Set("active", promotionCode.Active),
+		func() error {
+			if promotionCode.Coupon != nil {
+				return d.Set("coupon", promotionCode.Coupon.ID)
+			}
+			return nil
+		}(),
+		func() error {
+			if promotionCode.Customer != nil {
+				return d.Set("customer", promotionCode.Customer.ID)
+			}
+			return nil
+		}(),
+		d.Set("expires_at", ToRFC3339(promotionCode.ExpiresAt)),
+		d.Set("max_redemptions", promotionCode.MaxRedemptions),
+		d.Set("times_redeemed", promotionCode.TimesRedeemed),
+		func() error {
+			if promotionCode.Restrictions != nil {
+				return d.Set("restrictions", []map[string]interface{}{
+					{
+						"first_time_transaction":  promotionCode.Restrictions.FirstTimeTransaction,
+						"minimum_amount":          promotionCode.Restrictions.MinimumAmount,
+						"minimum_amount_currency": promotionCode.Restrictions.MinimumAmountCurrency,
+					},
+				})
+			}
+			return nil
+		}(),
+	)
+}
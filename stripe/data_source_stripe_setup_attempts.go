@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeSetupAttempts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeSetupAttemptsRead,
+		Schema: map[string]*schema.Schema{
+			"setup_intent": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the SetupIntent whose attempts are listed.",
+			},
+			"setup_attempts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The attempts of the SetupIntent, the most recent first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the object.",
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "Status of this SetupAttempt, one of requires_confirmation, requires_action, " +
+								"processing, succeeded, failed, or abandoned.",
+						},
+						"payment_method": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the payment method used with this SetupAttempt.",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time at which the object was created.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeSetupAttemptsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	setupIntent := ExtractString(d, "setup_intent")
+	params := &stripe.SetupAttemptListParams{
+		SetupIntent: stripe.String(setupIntent),
+	}
+	params.Limit = stripe.Int64(100)
+	params.Context = ctx
+
+	var setupAttempts []map[string]interface{}
+	it := c.SetupAttempts.List(params)
+	for it.Next() {
+		setupAttempt := it.SetupAttempt()
+		paymentMethod := ""
+		if setupAttempt.PaymentMethod != nil {
+			paymentMethod = setupAttempt.PaymentMethod.ID
+		}
+		setupAttempts = append(setupAttempts, map[string]interface{}{
+			"id":             setupAttempt.ID,
+			"status":         setupAttempt.Status,
+			"payment_method": paymentMethod,
+			"created":        ToRFC3339(setupAttempt.Created),
+		})
+	}
+	if err := it.Err(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(setupIntent)
+	return CallSet(
+		d.Set("setup_attempts", setupAttempts),
+	)
+}
@@ -0,0 +1,150 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeSubscription() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeSubscriptionRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the customer who owns the subscription.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the subscription, one of incomplete, incomplete_expired, trialing, " +
+					"active, past_due, canceled, or unpaid.",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The subscription items the customer is billed for.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the subscription item.",
+						},
+						"price": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the price the item is billed at.",
+						},
+						"quantity": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The quantity of the price the customer is subscribed to.",
+						},
+					},
+				},
+			},
+			"current_period_start": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start of the current period that the subscription has been invoiced for, in RFC3339 format.",
+			},
+			"current_period_end": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "End of the current period that the subscription has been invoiced for, in RFC3339 format. " +
+					"At the end of this period, a new invoice will be created.",
+			},
+			"cancel_at_period_end": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the subscription will be canceled at the end of the current period.",
+			},
+			"pause_collection": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "How the collection of payments is paused, empty when the collection isn't paused.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"behavior": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The behavior of the invoices created while paused, " +
+								"one of keep_as_draft, mark_uncollectible, or void.",
+						},
+						"resumes_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time after which the subscription resumes collecting payments, in RFC3339 format.",
+						},
+					},
+				},
+			},
+			"latest_invoice": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the most recent invoice this subscription has generated.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs attached to the object.",
+			},
+		},
+	}
+}
+
+func dataSourceStripeSubscriptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	subscription, err := c.Subscriptions.Get(ExtractString(d, "id"), &stripe.SubscriptionParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subscription.ID)
+	return CallSet(
+		func() error {
+			if subscription.Customer != nil {
+				return d.Set("customer", subscription.Customer.ID)
+			}
+			return nil
+		}(),
+		d.Set("status", subscription.Status),
+		func() error {
+			var items []map[string]interface{}
+			if subscription.Items != nil {
+				for _, item := range subscription.Items.Data {
+					itemMap := map[string]interface{}{
+						"id":       item.ID,
+						"quantity": item.Quantity,
+					}
+					if item.Price != nil {
+						itemMap["price"] = item.Price.ID
+					}
+					items = append(items, itemMap)
+				}
+			}
+			return d.Set("items", items)
+		}(),
+		d.Set("current_period_start", ToRFC3339(subscription.CurrentPeriodStart)),
+		d.Set("current_period_end", ToRFC3339(subscription.CurrentPeriodEnd)),
+		d.Set("cancel_at_period_end", subscription.CancelAtPeriodEnd),
+		d.Set("pause_collection", flattenSubscriptionPauseCollection(subscription.PauseCollection)),
+		func() error {
+			if subscription.LatestInvoice != nil {
+				return d.Set("latest_invoice", subscription.LatestInvoice.ID)
+			}
+			return nil
+		}(),
+		d.Set("metadata", subscription.Metadata),
+	)
+}
@@ -0,0 +1,89 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeTaxRate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeTaxRateRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The display name of the tax rate, shown to your customers on invoices.",
+			},
+			"percentage": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The tax rate percentage out of 100.",
+			},
+			"inclusive": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the tax rate is inclusive of the amounts it applies to.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the tax rate can be used for new purchases.",
+			},
+			"country": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Two-letter country code (ISO 3166-1 alpha-2).",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ISO 3166-2 subdivision code, without country prefix.",
+			},
+			"jurisdiction": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The jurisdiction of the tax rate, as it appears on customer invoices.",
+			},
+			"tax_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The high-level tax type, such as vat, gst or sales_tax.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs attached to the object.",
+			},
+		},
+	}
+}
+
+func dataSourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	taxRate, err := c.TaxRates.Get(ExtractString(d, "id"), &stripe.TaxRateParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(taxRate.ID)
+	return CallSet(
+		d.Set("display_name", taxRate.DisplayName),
+		d.Set("percentage", taxRate.Percentage),
+		d.Set("inclusive", taxRate.Inclusive),
+		d.Set("active", taxRate.Active),
+		d.Set("country", taxRate.Country),
+		d.Set("state", taxRate.State),
+		d.Set("jurisdiction", taxRate.Jurisdiction),
+		d.Set("tax_type", taxRate.TaxType),
+		d.Set("metadata", taxRate.Metadata),
+	)
+}
@@ -0,0 +1,73 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeWebhookEndpoint() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeWebhookEndpointRead,
+		// the secret isn't exposed, Stripe only returns it when the endpoint is created
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the webhook endpoint.",
+			},
+			"enabled_events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The list of events enabled for this endpoint.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the webhook. It can be enabled or disabled.",
+			},
+			"api_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API version events are rendered as for this webhook endpoint.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "An optional description of what the webhook is used for.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs attached to the object.",
+			},
+		},
+	}
+}
+
+func dataSourceStripeWebhookEndpointRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	webhookEndpoint, err := c.WebhookEndpoints.Get(ExtractString(d, "id"), &stripe.WebhookEndpointParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(webhookEndpoint.ID)
+	return CallSet(
+		d.Set("url", webhookEndpoint.URL),
+		d.Set("enabled_events", webhookEndpoint.EnabledEvents),
+		d.Set("status", webhookEndpoint.Status),
+		d.Set("api_version", webhookEndpoint.APIVersion),
+		d.Set("description", webhookEndpoint.Description),
+		d.Set("metadata", webhookEndpoint.Metadata),
+	)
+}
@@ -2,9 +2,14 @@ package stripe
 
 import (
 	"context"
+	"net/http"
+	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
 	"github.com/stripe/stripe-go/v72/client"
 )
 
@@ -14,25 +19,217 @@ func Provider() *schema.Provider {
 			"api_key": {
 				Type:        schema.TypeString,
 				Description: "The Stripe secret API key",
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("STRIPE_API_KEY", nil),
 			},
+			"max_network_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(stripe.DefaultMaxNetworkRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum number of times a request that failed due to an intermittent problem is retried. " +
+					"Write requests carry an idempotency key, so retrying them never duplicates objects.",
+			},
+			"api_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(\.\w+)?$`),
+					"expected a Stripe API version such as 2020-08-27"),
+				Description: "The Stripe API version used for all requests. " +
+					"Defaults to the version the Stripe SDK is built against.",
+			},
+			"api_base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "Overrides the base URL of the Stripe API and file uploads, " +
+					"e.g. to run against a local stripe-mock instance.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum number of times a request rejected by the Stripe rate limiter is retried, " +
+					"honoring the Retry-After header or backing off exponentially. Disabled by default.",
+			},
+			"http_timeout_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Timeout of a single HTTP request to Stripe in seconds, every retry gets the full timeout " +
+					"and the waits between rate limited attempts don't count. Defaults to the 80 seconds stripe-go uses.",
+			},
+			"stripe_account": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^acct_\w+$`),
+					"expected a Stripe account ID such as acct_1032D82eZvKYlo2C"),
+				Description: "The ID of a connected account all requests are made on behalf of, " +
+					"sent as the Stripe-Account header. Requires a platform API key.",
+			},
+			"minimal_reads": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Skips expanding related objects on refresh unless the resource sets the attribute " +
+					"they fill, to speed up the refresh of large states.",
+			},
+			"default_metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Metadata added to every object created or updated by the provider. " +
+					"Keys set in the metadata of a resource take precedence. " +
+					"A key added later only reaches an object the next time its metadata changes.",
+			},
+			"ignore_metadata_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Metadata keys added to objects outside of Terraform, e.g. by Stripe or other integrations, " +
+					"that are left out of the metadata of resources which don't set them.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"stripe_webhook_endpoint": resourceStripeWebhookEndpoint(),
-			"stripe_coupon":           resourceStripeCoupon(),
-			"stripe_product":          resourceStripeProduct(),
-			"stripe_promotion_code":   resourceStripePromotionCode(),
-			"stripe_price":            resourceStripePrice(),
-			"stripe_customer":         resourceStripeCustomer(),
-			"stripe_tax_rate":         resourceStripeTaxRate(),
+			"stripe_webhook_endpoint":                resourceStripeWebhookEndpoint(),
+			"stripe_coupon":                          resourceStripeCoupon(),
+			"stripe_product":                         resourceStripeProduct(),
+			"stripe_promotion_code":                  resourceStripePromotionCode(),
+			"stripe_price":                           resourceStripePrice(),
+			"stripe_customer":                        resourceStripeCustomer(),
+			"stripe_tax_rate":                        resourceStripeTaxRate(),
+			"stripe_plan":                            resourceStripePlan(),
+			"stripe_file":                            resourceStripeFile(),
+			"stripe_customer_balance_transaction":    resourceStripeCustomerBalanceTransaction(),
+			"stripe_checkout_session":                resourceStripeCheckoutSession(),
+			"stripe_payment_method":                  resourceStripePaymentMethod(),
+			"stripe_subscription_schedule":           resourceStripeSubscriptionSchedule(),
+			"stripe_subscription":                    resourceStripeSubscription(),
+			"stripe_credit_note":                     resourceStripeCreditNote(),
+			"stripe_invoice":                         resourceStripeInvoice(),
+			"stripe_connect_account":                 resourceStripeConnectAccount(),
+			"stripe_radar_value_list":                resourceStripeRadarValueList(),
+			"stripe_radar_value_list_item":           resourceStripeRadarValueListItem(),
+			"stripe_quote":                           resourceStripeQuote(),
+			"stripe_customer_session":                resourceStripeCustomerSession(),
+			"stripe_tax_settings":                    resourceStripeTaxSettings(),
+			"stripe_usage_record":                    resourceStripeUsageRecord(),
+			"stripe_subscription_item":               resourceStripeSubscriptionItem(),
+			"stripe_setup_intent":                    resourceStripeSetupIntent(),
+			"stripe_payment_intent":                  resourceStripePaymentIntent(),
+			"stripe_account_link":                    resourceStripeAccountLink(),
+			"stripe_billing_portal_session":          resourceStripeBillingPortalSession(),
+			"stripe_payout":                          resourceStripePayout(),
+			"stripe_terminal_configuration":          resourceStripeTerminalConfiguration(),
+			"stripe_customer_cash_balance":           resourceStripeCustomerCashBalance(),
+			"stripe_source":                          resourceStripeSource(),
+			"stripe_transfer":                        resourceStripeTransfer(),
+			"stripe_topup":                           resourceStripeTopup(),
+			"stripe_dispute":                         resourceStripeDispute(),
+			"stripe_order":                           resourceStripeOrder(),
+			"stripe_review":                          resourceStripeReview(),
+			"stripe_billing_credit_grant":            resourceStripeBillingCreditGrant(),
+			"stripe_customer_default_payment_method": resourceStripeCustomerDefaultPaymentMethod(),
+			"stripe_payment_link":                    resourceStripePaymentLink(),
+			"stripe_entitlements_feature":            resourceStripeEntitlementsFeature(),
+			"stripe_product_feature":                 resourceStripeProductFeature(),
+			"stripe_billing_meter":                   resourceStripeBillingMeter(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"stripe_coupon":              dataSourceStripeCoupon(),
+			"stripe_customer":            dataSourceStripeCustomer(),
+			"stripe_products":            dataSourceStripeProducts(),
+			"stripe_price":               dataSourceStripePrice(),
+			"stripe_webhook_endpoint":    dataSourceStripeWebhookEndpoint(),
+			"stripe_promotion_code":      dataSourceStripePromotionCode(),
+			"stripe_charge":              dataSourceStripeCharge(),
+			"stripe_balance":             dataSourceStripeBalance(),
+			"stripe_tax_rate":            dataSourceStripeTaxRate(),
+			"stripe_account":             dataSourceStripeAccount(),
+			"stripe_active_entitlements": dataSourceStripeActiveEntitlements(),
+			"stripe_subscription":        dataSourceStripeSubscription(),
+			"stripe_invoice":             dataSourceStripeInvoice(),
+			"stripe_mandate":             dataSourceStripeMandate(),
+			"stripe_setup_attempts":      dataSourceStripeSetupAttempts(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	key := ExtractString(d, "api_key")
-	return client.New(key, nil), nil
+	if key == "" {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Missing Stripe API key",
+			Detail:   "Set the api_key provider argument or the STRIPE_API_KEY environment variable.",
+		}}
+	}
+
+	timeout := defaultHTTPTimeout
+	if seconds, set := d.GetOk("http_timeout_seconds"); set {
+		timeout = time.Duration(ToInt(seconds)) * time.Second
+	}
+
+	// the timeout applies to every attempt, the waits between rate limited attempts don't count
+	var transport http.RoundTripper = &timeoutTransport{
+		timeout: timeout,
+		next:    http.DefaultTransport,
+	}
+	if apiVersion, set := d.GetOk("api_version"); set {
+		transport = &apiVersionTransport{
+			version: ToString(apiVersion),
+			next:    transport,
+		}
+	}
+	if account, set := d.GetOk("stripe_account"); set {
+		transport = &stripeAccountTransport{
+			account: ToString(account),
+			next:    transport,
+		}
+	}
+	if maxRetries := ExtractInt(d, "max_retries"); maxRetries > 0 {
+		transport = &rateLimitTransport{
+			maxRetries: maxRetries,
+			next:       transport,
+		}
+	}
+
+	// the HTTP client has no timeout of its own, the transports use http.DefaultTransport in the end,
+	// which honors the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+	httpClient := &http.Client{Transport: transport}
+
+	// every backend needs its own config, stripe-go fills in the backend specific URL
+	backendConfig := func() *stripe.BackendConfig {
+		return &stripe.BackendConfig{
+			HTTPClient:        httpClient,
+			MaxNetworkRetries: stripe.Int64(ExtractInt64(d, "max_network_retries")),
+		}
+	}
+	apiConfig, uploadsConfig := backendConfig(), backendConfig()
+	if baseURL, set := d.GetOk("api_base_url"); set {
+		apiConfig.URL = stripe.String(ToString(baseURL))
+		uploadsConfig.URL = stripe.String(ToString(baseURL))
+	}
+	backends := &stripe.Backends{
+		API:     stripe.GetBackendWithConfig(stripe.APIBackend, apiConfig),
+		Connect: stripe.GetBackendWithConfig(stripe.ConnectBackend, backendConfig()),
+		Uploads: stripe.GetBackendWithConfig(stripe.UploadsBackend, uploadsConfig),
+	}
+
+	config := &Config{
+		API:                client.New(key, backends),
+		DefaultMetadata:    map[string]string{},
+		IgnoreMetadataKeys: map[string]bool{},
+		MinimalReads:       ExtractBool(d, "minimal_reads"),
+	}
+	for k, v := range ExtractMap(d, "default_metadata") {
+		config.DefaultMetadata[k] = ToString(v)
+	}
+	for _, k := range d.Get("ignore_metadata_keys").(*schema.Set).List() {
+		config.IgnoreMetadataKeys[ToString(k)] = true
+	}
+	return config, nil
 }
@@ -0,0 +1,366 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// testAccProvider is shared by the acceptance tests, it's configured by the first test step
+// and gives the CheckDestroy functions access to the Stripe client.
+var testAccProvider *schema.Provider
+
+var testAccProviderFactories map[string]func() (*schema.Provider, error)
+
+func init() {
+	testAccProvider = Provider()
+	testAccProviderFactories = map[string]func() (*schema.Provider, error){
+		"stripe": func() (*schema.Provider, error) {
+			return testAccProvider, nil
+		},
+	}
+}
+
+// TestProvider_InternalValidate catches schema mistakes, like conflicting flags on a field,
+// without calling Stripe.
+func TestProvider_InternalValidate(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("provider schema is invalid: %s", err)
+	}
+}
+
+// testAccPreCheck stops acceptance tests early when there's no API key to run them with,
+// use a test mode key, the tests create and delete real objects.
+func testAccPreCheck(t *testing.T) {
+	if os.Getenv("STRIPE_API_KEY") == "" {
+		t.Fatal("STRIPE_API_KEY must be set for acceptance tests")
+	}
+}
+
+// testStripeAPI returns a client sending its requests to a local server standing in for Stripe,
+// for unit tests of the resource functions.
+func testStripeAPI(t *testing.T, handler http.HandlerFunc) *client.API {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	backend := stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+		URL:               stripe.String(server.URL),
+		MaxNetworkRetries: stripe.Int64(0),
+	})
+	return client.New("sk_test_123", &stripe.Backends{API: backend, Connect: backend, Uploads: backend})
+}
+
+// TestProvider_requestContext checks that the context Terraform hands to the resource functions reaches
+// the Stripe requests, a cancelled or expired context aborts the call instead of waiting for Stripe.
+func TestProvider_requestContext(t *testing.T) {
+	calls := map[string]func(context.Context, *Config) diag.Diagnostics{
+		"create customer": func(ctx context.Context, config *Config) diag.Diagnostics {
+			d := schema.TestResourceDataRaw(t, resourceStripeCustomer().Schema, map[string]interface{}{"name": "test"})
+			return resourceStripeCustomerCreate(ctx, d, config)
+		},
+		"read price": func(ctx context.Context, config *Config) diag.Diagnostics {
+			d := resourceStripePrice().Data(nil)
+			d.SetId("price_123")
+			return resourceStripePriceRead(ctx, d, config)
+		},
+		"read order": func(ctx context.Context, config *Config) diag.Diagnostics {
+			d := resourceStripeOrder().Data(nil)
+			d.SetId("order_123")
+			return resourceStripeOrderRead(ctx, d, config)
+		},
+		"delete coupon": func(ctx context.Context, config *Config) diag.Diagnostics {
+			d := resourceStripeCoupon().Data(nil)
+			d.SetId("coupon_123")
+			return resourceStripeCouponDelete(ctx, d, config)
+		},
+		"delete default payment method": func(ctx context.Context, config *Config) diag.Diagnostics {
+			d := resourceStripeCustomerDefaultPaymentMethod().Data(nil)
+			d.SetId("cus_123")
+			return resourceStripeCustomerDefaultPaymentMethodDelete(ctx, d, config)
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name+"/cancelled", func(t *testing.T) {
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("%s %s reached Stripe with a cancelled context", r.Method, r.URL.Path)
+			})
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			diags := call(ctx, &Config{API: api})
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, context.Canceled.Error()) {
+				t.Errorf("expected a %q error, got %v", context.Canceled, diags)
+			}
+		})
+
+		t.Run(name+"/expired", func(t *testing.T) {
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				// Stripe never answers, only the deadline ends the request,
+				// the body is read first for the server to notice the client going away
+				_, _ = io.Copy(io.Discard, r.Body)
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			diags := call(ctx, &Config{API: api})
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, context.DeadlineExceeded.Error()) {
+				t.Errorf("expected a %q error, got %v", context.DeadlineExceeded, diags)
+			}
+		})
+	}
+}
+
+// testProviderConfig configures the provider from the raw provider block, for unit tests of the provider arguments.
+func testProviderConfig(t *testing.T, raw map[string]interface{}) *Config {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	return meta.(*Config)
+}
+
+// TestProvider_maxNetworkRetries counts the attempts of a request Stripe keeps answering with a conflict,
+// which stripe-go retries.
+func TestProvider_maxNetworkRetries(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxRetries int
+		attempts   int
+	}{
+		{"no retries", 0, 1},
+		{"one retry", 1, 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "Conflict"}}`)
+			}))
+			defer server.Close()
+
+			config := testProviderConfig(t, map[string]interface{}{
+				"api_key":             "sk_test_123",
+				"api_base_url":        server.URL,
+				"max_network_retries": tc.maxRetries,
+			})
+			if _, err := config.API.Balance.Get(nil); err == nil {
+				t.Fatal("expected the conflict to fail the request")
+			}
+			if attempts != tc.attempts {
+				t.Errorf("expected %d attempts, got %d", tc.attempts, attempts)
+			}
+		})
+	}
+}
+
+// TestProvider_apiVersion checks the Stripe-Version the requests arrive with.
+func TestProvider_apiVersion(t *testing.T) {
+	cases := []struct {
+		name       string
+		apiVersion string
+		expected   string
+	}{
+		{"default", "", stripe.APIVersion},
+		{"configured", "2020-08-27", "2020-08-27"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var version string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				version = r.Header.Get("Stripe-Version")
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"object": "balance"}`)
+			}))
+			defer server.Close()
+
+			raw := map[string]interface{}{
+				"api_key":      "sk_test_123",
+				"api_base_url": server.URL,
+			}
+			if tc.apiVersion != "" {
+				raw["api_version"] = tc.apiVersion
+			}
+			config := testProviderConfig(t, raw)
+			if _, err := config.API.Balance.Get(nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if version != tc.expected {
+				t.Errorf("expected Stripe-Version %q, got %q", tc.expected, version)
+			}
+		})
+	}
+}
+
+// TestProvider_stripeAccount checks the Stripe-Account the requests arrive with, the account a request
+// sets itself wins over stripe_account.
+func TestProvider_stripeAccount(t *testing.T) {
+	cases := []struct {
+		name          string
+		stripeAccount string
+		paramsAccount string
+		expected      string
+	}{
+		{"default", "", "", ""},
+		{"configured", "acct_1032D82eZvKYlo2C", "", "acct_1032D82eZvKYlo2C"},
+		{"request", "acct_1032D82eZvKYlo2C", "acct_2043E93fAwLZmp3D", "acct_2043E93fAwLZmp3D"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var account string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				account = r.Header.Get("Stripe-Account")
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"object": "balance"}`)
+			}))
+			defer server.Close()
+
+			raw := map[string]interface{}{
+				"api_key":      "sk_test_123",
+				"api_base_url": server.URL,
+			}
+			if tc.stripeAccount != "" {
+				raw["stripe_account"] = tc.stripeAccount
+			}
+			config := testProviderConfig(t, raw)
+			params := &stripe.BalanceParams{}
+			if tc.paramsAccount != "" {
+				params.SetStripeAccount(tc.paramsAccount)
+			}
+			if _, err := config.API.Balance.Get(params); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if account != tc.expected {
+				t.Errorf("expected Stripe-Account %q, got %q", tc.expected, account)
+			}
+		})
+	}
+}
+
+// TestProvider_httpTimeout checks that http_timeout_seconds fails a request Stripe doesn't answer in time.
+func TestProvider_httpTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"object": "balance"}`)
+	}))
+	defer server.Close()
+
+	config := testProviderConfig(t, map[string]interface{}{
+		"api_key":              "sk_test_123",
+		"api_base_url":         server.URL,
+		"max_network_retries":  0,
+		"http_timeout_seconds": 1,
+	})
+	start := time.Now()
+	if _, err := config.API.Balance.Get(nil); err == nil {
+		t.Fatal("expected the stalled request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the request to give up after 1s, it took %s", elapsed)
+	}
+}
+
+// TestProvider_missingAPIKey checks that the provider fails to configure without an API key,
+// instead of every request failing with an authentication error later on.
+func TestProvider_missingAPIKey(t *testing.T) {
+	t.Setenv("STRIPE_API_KEY", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	meta, diags := providerConfigure(context.Background(), d)
+	if meta != nil {
+		t.Errorf("expected no provider meta, got %v", meta)
+	}
+	if !diags.HasError() || diags[0].Summary != "Missing Stripe API key" {
+		t.Errorf("expected a missing API key error, got %v", diags)
+	}
+
+	t.Setenv("STRIPE_API_KEY", "sk_test_123")
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	if _, diags := providerConfigure(context.Background(), d); diags.HasError() {
+		t.Errorf("expected the key of STRIPE_API_KEY to be used, got %v", diags)
+	}
+}
+
+// TestProvider_apiBaseURL checks that api_base_url points the API and the file uploads to the same server.
+func TestProvider_apiBaseURL(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"object": "balance"}`)
+	}))
+	defer server.Close()
+
+	config := testProviderConfig(t, map[string]interface{}{
+		"api_key":      "sk_test_123",
+		"api_base_url": server.URL,
+	})
+	if _, err := config.API.Balance.Get(nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if path != "/v1/balance" {
+		t.Errorf("expected the balance to be read from %s, got %q", server.URL, path)
+	}
+
+	if url := config.API.Files.B.(*stripe.BackendImplementation).URL; url != server.URL {
+		t.Errorf("expected the uploads sent to %s, got %s", server.URL, url)
+	}
+}
+
+// TestProvider_readNotFound refreshes objects deleted outside of Terraform, every resource drops them from the state.
+func TestProvider_readNotFound(t *testing.T) {
+	reads := map[string]struct {
+		resource *schema.Resource
+		read     schema.ReadContextFunc
+	}{
+		"customer":       {resourceStripeCustomer(), resourceStripeCustomerRead},
+		"plan":           {resourceStripePlan(), resourceStripePlanRead},
+		"price":          {resourceStripePrice(), resourceStripePriceRead},
+		"product":        {resourceStripeProduct(), resourceStripeProductRead},
+		"promotion code": {resourceStripePromotionCode(), resourceStripePromotionCodeRead},
+		"tax rate":       {resourceStripeTaxRate(), resourceStripeTaxRateRead},
+		"webhook":        {resourceStripeWebhookEndpoint(), resourceStripeWebhookEndpointRead},
+	}
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "code": "resource_missing", "message": "No such object"}}`)
+	})
+
+	for name, tc := range reads {
+		t.Run(name, func(t *testing.T) {
+			d := tc.resource.Data(nil)
+			d.SetId("test")
+			if diags := tc.read(context.Background(), d, &Config{API: api}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != "" {
+				t.Errorf("expected the %s removed from the state", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeAccountLink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeAccountLinkRead,
+		CreateContext: resourceStripeAccountLinkCreate,
+		DeleteContext: resourceStripeAccountLinkDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the link, made of the account ID and the creation time.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"account": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier of the account to create an account link for.",
+			},
+			"refresh_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "The URL the user will be redirected to if the account link is expired, " +
+					"has been previously-visited, or is otherwise invalid.",
+			},
+			"return_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL that the user will be redirected to upon leaving or completing the linked flow.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.AccountLinkTypeAccountOnboarding),
+					string(stripe.AccountLinkTypeAccountUpdate),
+				}, false),
+				Description: "The type of account link the user is requesting, " +
+					"either account_onboarding or account_update.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL for the account link.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time at which this account link will expire, in RFC3339 format.",
+			},
+		},
+	}
+}
+
+// resourceStripeAccountLinkRead doesn't call Stripe, account links can't be retrieved.
+// Expired links are removed from the state so that the next apply creates a fresh one.
+func resourceStripeAccountLinkRead(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	expiresAt, err := time.Parse(time.RFC3339, ExtractString(d, "expires_at"))
+	if err == nil && !expiresAt.After(time.Now()) {
+		log.Printf("[WARN] Account Link %s expired at %s, removing it from the state", d.Id(), expiresAt)
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceStripeAccountLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.AccountLinkParams{
+		Account:    stripe.String(ExtractString(d, "account")),
+		RefreshURL: stripe.String(ExtractString(d, "refresh_url")),
+		ReturnURL:  stripe.String(ExtractString(d, "return_url")),
+		Type:       stripe.String(ExtractString(d, "type")),
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	accountLink, err := c.AccountLinks.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", ExtractString(d, "account"), accountLink.Created))
+	return CallSet(
+		d.Set("url", accountLink.URL),
+		d.Set("expires_at", ToRFC3339(accountLink.ExpiresAt)),
+	)
+}
+
+func resourceStripeAccountLinkDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// account links expire on their own and can't be revoked through the API
+	d.SetId("")
+	return nil
+}
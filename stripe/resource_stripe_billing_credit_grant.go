@@ -0,0 +1,364 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// stripe-go v72 predates billing credits, the credit grants are sent through the backend directly
+type billingCreditGrantMonetaryParams struct {
+	Currency *string `form:"currency"`
+	Value    *int64  `form:"value"`
+}
+
+type billingCreditGrantAmountParams struct {
+	Monetary *billingCreditGrantMonetaryParams `form:"monetary"`
+	Type     *string                           `form:"type"`
+}
+
+type billingCreditGrantScopeParams struct {
+	PriceType *string `form:"price_type"`
+}
+
+type billingCreditGrantApplicabilityConfigParams struct {
+	Scope *billingCreditGrantScopeParams `form:"scope"`
+}
+
+type billingCreditGrantParams struct {
+	stripe.Params       `form:"*"`
+	Amount              *billingCreditGrantAmountParams              `form:"amount"`
+	ApplicabilityConfig *billingCreditGrantApplicabilityConfigParams `form:"applicability_config"`
+	Category            *string                                      `form:"category"`
+	Customer            *string                                      `form:"customer"`
+	ExpiresAt           *int64                                       `form:"expires_at"`
+	Priority            *int64                                       `form:"priority"`
+}
+
+type billingCreditGrant struct {
+	stripe.APIResource
+	ID     string `json:"id"`
+	Amount *struct {
+		Monetary *struct {
+			Currency stripe.Currency `json:"currency"`
+			Value    int64           `json:"value"`
+		} `json:"monetary"`
+		Type string `json:"type"`
+	} `json:"amount"`
+	ApplicabilityConfig *struct {
+		Scope *struct {
+			PriceType string `json:"price_type"`
+		} `json:"scope"`
+	} `json:"applicability_config"`
+	Category    string            `json:"category"`
+	Customer    *stripe.Customer  `json:"customer"`
+	EffectiveAt int64             `json:"effective_at"`
+	ExpiresAt   int64             `json:"expires_at"`
+	Metadata    map[string]string `json:"metadata"`
+	Priority    int64             `json:"priority"`
+	VoidedAt    int64             `json:"voided_at"`
+}
+
+func resourceStripeBillingCreditGrant() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeBillingCreditGrantRead,
+		CreateContext: resourceStripeBillingCreditGrantCreate,
+		UpdateContext: resourceStripeBillingCreditGrantUpdate,
+		DeleteContext: resourceStripeBillingCreditGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the customer to receive the billing credits.",
+			},
+			"amount": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Amount of this credit grant.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "monetary",
+							ValidateFunc: validation.StringInSlice([]string{"monetary"}, false),
+							Description:  "The type of this amount, only monetary is supported.",
+						},
+						"monetary": {
+							Type:        schema.TypeList,
+							Required:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Description: "The monetary amount.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"currency": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validateCurrency,
+										Description:  "Three-letter ISO currency code, in lowercase.",
+									},
+									"value": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										Description:  "A positive integer representing the amount in the smallest currency unit.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"category": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"paid",
+					"promotional",
+				}, false),
+				Description: "The category of this credit grant, either paid or promotional.",
+			},
+			"applicability_config": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Configuration specifying what this credit grant applies to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"scope": {
+							Type:        schema.TypeList,
+							Required:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Description: "Specify the scope of this credit grant.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"price_type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.StringInSlice([]string{"metered"}, false),
+										Description:  "The price type the credit grant applies to, only metered is supported.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"expires_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateRFC3339,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+				Description:      "The time when the billing credits expire. If not set, the credits never expire.",
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+				Description: "The lower the number, the sooner the credit grant is applied. " +
+					"Defaults to 50.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"effective_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time when the billing credits become effective, i.e. when they're eligible for use.",
+			},
+		},
+	}
+}
+
+func resourceStripeBillingCreditGrantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	grant := &billingCreditGrant{}
+	path := stripe.FormatURLPath("/v1/billing/credit_grants/%s", d.Id())
+	err := rawCall(m, http.MethodGet, path, &stripe.Params{Context: ctx}, grant)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		func() error {
+			if grant.Customer != nil {
+				return d.Set("customer", grant.Customer.ID)
+			}
+			return nil
+		}(),
+		func() error {
+			if grant.Amount != nil {
+				amount := map[string]interface{}{
+					"type": grant.Amount.Type,
+				}
+				if grant.Amount.Monetary != nil {
+					amount["monetary"] = []map[string]interface{}{
+						{
+							"currency": grant.Amount.Monetary.Currency,
+							"value":    grant.Amount.Monetary.Value,
+						},
+					}
+				}
+				return d.Set("amount", []map[string]interface{}{amount})
+			}
+			return nil
+		}(),
+		d.Set("category", grant.Category),
+		func() error {
+			if grant.ApplicabilityConfig != nil && grant.ApplicabilityConfig.Scope != nil {
+				return d.Set("applicability_config", []map[string]interface{}{
+					{
+						"scope": []map[string]interface{}{
+							{
+								"price_type": grant.ApplicabilityConfig.Scope.PriceType,
+							},
+						},
+					},
+				})
+			}
+			return nil
+		}(),
+		d.Set("expires_at", ToRFC3339(grant.ExpiresAt)),
+		d.Set("priority", grant.Priority),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(grant.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("effective_at", ToRFC3339(grant.EffectiveAt)),
+	)
+}
+
+func resourceStripeBillingCreditGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	amount := ToMap(d.Get("amount"))
+	monetary := ToMap(amount["monetary"])
+	scope := ToMap(ToMap(d.Get("applicability_config"))["scope"])
+	params := &billingCreditGrantParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+		Amount: &billingCreditGrantAmountParams{
+			Type: stripe.String(ToString(amount["type"])),
+			Monetary: &billingCreditGrantMonetaryParams{
+				Currency: stripe.String(ToString(monetary["currency"])),
+				Value:    stripe.Int64(ToInt64(monetary["value"])),
+			},
+		},
+		ApplicabilityConfig: &billingCreditGrantApplicabilityConfigParams{
+			Scope: &billingCreditGrantScopeParams{
+				PriceType: stripe.String(ToString(scope["price_type"])),
+			},
+		},
+		Category: stripe.String(ExtractString(d, "category")),
+	}
+
+	if expiresAt, set := d.GetOk("expires_at"); set {
+		t, err := time.Parse(time.RFC3339, ToString(expiresAt))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		params.ExpiresAt = stripe.Int64(t.Unix())
+	}
+	if priority, set := d.GetOkExists("priority"); set {
+		params.Priority = stripe.Int64(ToInt64(priority))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	grant := &billingCreditGrant{}
+	err := rawCall(m, http.MethodPost, "/v1/billing/credit_grants", params, grant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(grant.ID)
+	return resourceStripeBillingCreditGrantRead(ctx, d, m)
+}
+
+func resourceStripeBillingCreditGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &billingCreditGrantParams{}
+
+	if !d.HasChanges("expires_at", "metadata") {
+		return resourceStripeBillingCreditGrantRead(ctx, d, m)
+	}
+
+	if d.HasChange("expires_at") {
+		if expiresAt, set := d.GetOk("expires_at"); set {
+			t, err := time.Parse(time.RFC3339, ToString(expiresAt))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			params.ExpiresAt = stripe.Int64(t.Unix())
+		} else {
+			params.AddExtra("expires_at", "")
+		}
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	path := stripe.FormatURLPath("/v1/billing/credit_grants/%s", d.Id())
+	params.Context = ctx
+	err := rawCall(m, http.MethodPost, path, params, &billingCreditGrant{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeBillingCreditGrantRead(ctx, d, m)
+}
+
+func resourceStripeBillingCreditGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// a grant voided outside of Terraform can't be voided again
+	grant := &billingCreditGrant{}
+	path := stripe.FormatURLPath("/v1/billing/credit_grants/%s", d.Id())
+	err := rawCall(m, http.MethodGet, path, &stripe.Params{Context: ctx}, grant)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if grant.VoidedAt != 0 {
+		log.Printf("[WARN] Credit grant %s is already voided, removing it from the state", d.Id())
+	} else {
+		path = stripe.FormatURLPath("/v1/billing/credit_grants/%s/void", d.Id())
+		err = rawCall(m, http.MethodPost, path, &stripe.Params{Context: ctx}, &billingCreditGrant{})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,284 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// stripe-go v72 predates billing meters, the meters are sent through the backend directly
+type billingMeterDefaultAggregationParams struct {
+	Formula *string `form:"formula"`
+}
+
+type billingMeterCustomerMappingParams struct {
+	EventPayloadKey *string `form:"event_payload_key"`
+	Type            *string `form:"type"`
+}
+
+type billingMeterValueSettingsParams struct {
+	EventPayloadKey *string `form:"event_payload_key"`
+}
+
+type billingMeterParams struct {
+	stripe.Params      `form:"*"`
+	CustomerMapping    *billingMeterCustomerMappingParams    `form:"customer_mapping"`
+	DefaultAggregation *billingMeterDefaultAggregationParams `form:"default_aggregation"`
+	DisplayName        *string                               `form:"display_name"`
+	EventName          *string                               `form:"event_name"`
+	ValueSettings      *billingMeterValueSettingsParams      `form:"value_settings"`
+}
+
+type billingMeter struct {
+	stripe.APIResource
+	ID              string `json:"id"`
+	CustomerMapping *struct {
+		EventPayloadKey string `json:"event_payload_key"`
+		Type            string `json:"type"`
+	} `json:"customer_mapping"`
+	DefaultAggregation *struct {
+		Formula string `json:"formula"`
+	} `json:"default_aggregation"`
+	DisplayName   string `json:"display_name"`
+	EventName     string `json:"event_name"`
+	Status        string `json:"status"`
+	ValueSettings *struct {
+		EventPayloadKey string `json:"event_payload_key"`
+	} `json:"value_settings"`
+}
+
+func resourceStripeBillingMeter() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeBillingMeterRead,
+		CreateContext: resourceStripeBillingMeterCreate,
+		UpdateContext: resourceStripeBillingMeterUpdate,
+		DeleteContext: resourceStripeBillingMeterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"display_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 250),
+				Description:  "The meter's name, not visible to the customer.",
+			},
+			"event_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+				Description:  "The name of the meter event to record usage for, the event_name field of the meter events.",
+			},
+			"default_aggregation": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "The default settings to aggregate a meter's events with.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"formula": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"count",
+								"last",
+								"sum",
+							}, false),
+							Description: "Specifies how events are aggregated, one of count, last, or sum.",
+						},
+					},
+				},
+			},
+			"customer_mapping": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Fields that specify how to map a meter event to a customer.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event_payload_key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The key in the meter event payload to use for mapping the event to a customer.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "by_id",
+							ValidateFunc: validation.StringInSlice([]string{"by_id"}, false),
+							Description:  "The method for mapping a meter event to a customer, only by_id is supported.",
+						},
+					},
+				},
+			},
+			"value_settings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Fields that specify how to calculate a meter event's value.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event_payload_key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The key in the meter event payload to use as the value for this meter.",
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The meter's status, either active or inactive.",
+			},
+		},
+	}
+}
+
+func resourceStripeBillingMeterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meter := &billingMeter{}
+	path := stripe.FormatURLPath("/v1/billing/meters/%s", d.Id())
+	err := rawCall(m, http.MethodGet, path, &stripe.Params{Context: ctx}, meter)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("display_name", meter.DisplayName),
+		d.Set("event_name", meter.EventName),
+		func() error {
+			if meter.DefaultAggregation != nil {
+				return d.Set("default_aggregation", []map[string]interface{}{
+					{
+						"formula": meter.DefaultAggregation.Formula,
+					},
+				})
+			}
+			return nil
+		}(),
+		func() error {
+			if meter.CustomerMapping != nil {
+				return d.Set("customer_mapping", []map[string]interface{}{
+					{
+						"event_payload_key": meter.CustomerMapping.EventPayloadKey,
+						"type":              meter.CustomerMapping.Type,
+					},
+				})
+			}
+			return nil
+		}(),
+		func() error {
+			if meter.ValueSettings != nil {
+				return d.Set("value_settings", []map[string]interface{}{
+					{
+						"event_payload_key": meter.ValueSettings.EventPayloadKey,
+					},
+				})
+			}
+			return nil
+		}(),
+		d.Set("status", meter.Status),
+	)
+}
+
+func resourceStripeBillingMeterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defaultAggregation := ToMap(ToSlice(d.Get("default_aggregation"))[0])
+	params := &billingMeterParams{
+		DisplayName: stripe.String(ExtractString(d, "display_name")),
+		EventName:   stripe.String(ExtractString(d, "event_name")),
+		DefaultAggregation: &billingMeterDefaultAggregationParams{
+			Formula: stripe.String(ToString(defaultAggregation["formula"])),
+		},
+	}
+
+	if customerMapping, set := d.GetOk("customer_mapping"); set {
+		mapping := ToMap(ToSlice(customerMapping)[0])
+		params.CustomerMapping = &billingMeterCustomerMappingParams{
+			EventPayloadKey: stripe.String(ToString(mapping["event_payload_key"])),
+			Type:            stripe.String(ToString(mapping["type"])),
+		}
+	}
+	if valueSettings, set := d.GetOk("value_settings"); set {
+		settings := ToMap(ToSlice(valueSettings)[0])
+		params.ValueSettings = &billingMeterValueSettingsParams{
+			EventPayloadKey: stripe.String(ToString(settings["event_payload_key"])),
+		}
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	meter := &billingMeter{}
+	err := rawCall(m, http.MethodPost, "/v1/billing/meters", params, meter)
+	if err != nil {
+		return diagFromStripeErr(err)
+	}
+
+	d.SetId(meter.ID)
+	return resourceStripeBillingMeterRead(ctx, d, m)
+}
+
+// resourceStripeBillingMeterUpdate only changes the display_name, Stripe doesn't update the other arguments.
+func resourceStripeBillingMeterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if !d.HasChange("display_name") {
+		return resourceStripeBillingMeterRead(ctx, d, m)
+	}
+
+	params := &billingMeterParams{
+		DisplayName: stripe.String(ExtractString(d, "display_name")),
+	}
+	params.Context = ctx
+	path := stripe.FormatURLPath("/v1/billing/meters/%s", d.Id())
+	err := rawCall(m, http.MethodPost, path, params, &billingMeter{})
+	if err != nil {
+		return diagFromStripeErr(err)
+	}
+
+	return resourceStripeBillingMeterRead(ctx, d, m)
+}
+
+// resourceStripeBillingMeterDelete deactivates the meter, Stripe doesn't delete them.
+func resourceStripeBillingMeterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if ExtractString(d, "status") == "inactive" {
+		log.Printf("[WARN] Billing meter %s is already inactive, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	path := stripe.FormatURLPath("/v1/billing/meters/%s/deactivate", d.Id())
+	err := rawCall(m, http.MethodPost, path, &stripe.Params{Context: ctx}, &billingMeter{})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
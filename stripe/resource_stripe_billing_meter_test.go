@@ -0,0 +1,92 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestResourceStripeBillingMeter_update checks that only the display_name of a meter is changed in place,
+// the other arguments replace it, and that destroying the meter deactivates it.
+func TestResourceStripeBillingMeter_update(t *testing.T) {
+	var requests []string
+	displayName := "API calls"
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm))
+		if name := r.PostForm.Get("display_name"); name != "" {
+			displayName = name
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "mtr_123", "object": "billing.meter", "display_name": %q, "event_name": "api_call",
+  "default_aggregation": {"formula": "sum"}, "status": "active",
+  "customer_mapping": {"event_payload_key": "stripe_customer_id", "type": "by_id"},
+  "value_settings": {"event_payload_key": "value"}}`, displayName)
+	})
+	config := &Config{API: api}
+	r := resourceStripeBillingMeter()
+
+	diff := func(state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceDiff {
+		t.Helper()
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return diff
+	}
+	apply := func(state *terraform.InstanceState, diff *terraform.InstanceDiff) *terraform.InstanceState {
+		t.Helper()
+		state, diags := r.Apply(context.Background(), state, diff, config)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return state
+	}
+
+	meter := map[string]interface{}{
+		"display_name":        "API calls",
+		"event_name":          "api_call",
+		"default_aggregation": []interface{}{map[string]interface{}{"formula": "sum"}},
+		"idempotency_key":     "test",
+	}
+	state := apply(nil, diff(nil, meter))
+
+	meter["display_name"] = "Metered API calls"
+	changeName := diff(state, meter)
+	if changeName.RequiresNew() {
+		t.Errorf("expected the display_name changed in place, got %#v", changeName.Attributes)
+	}
+	state = apply(state, changeName)
+	if name := state.Attributes["display_name"]; name != "Metered API calls" {
+		t.Errorf("expected the display_name updated, got %q", name)
+	}
+
+	changeEvent := map[string]interface{}{}
+	for k, v := range meter {
+		changeEvent[k] = v
+	}
+	changeEvent["event_name"] = "api_request"
+	if !diff(state, changeEvent).RequiresNew() {
+		t.Error("expected a change of event_name to replace the meter")
+	}
+
+	if diags := resourceStripeBillingMeterDelete(context.Background(), r.Data(state), config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		"POST /v1/billing/meters map[default_aggregation[formula]:[sum] display_name:[API calls] event_name:[api_call]]",
+		"GET /v1/billing/meters/mtr_123 map[]",
+		"POST /v1/billing/meters/mtr_123 map[display_name:[Metered API calls]]",
+		"GET /v1/billing/meters/mtr_123 map[]",
+		"POST /v1/billing/meters/mtr_123/deactivate map[]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,102 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeBillingPortalSession() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeBillingPortalSessionRead,
+		CreateContext: resourceStripeBillingPortalSessionCreate,
+		DeleteContext: resourceStripeBillingPortalSessionDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of an existing customer.",
+			},
+			"configuration": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The ID of an existing billing portal configuration to use for this session. " +
+					"Defaults to the default configuration of the account.",
+			},
+			"return_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The default URL to redirect customers to when they click on the portal’s link to return to your website.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The short-lived URL of the session that gives customers access to the customer portal.",
+			},
+			"created": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Time at which the object was created. Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+// resourceStripeBillingPortalSessionRead keeps the state as it is,
+// Stripe has no endpoint to retrieve a billing portal session after it is created.
+func resourceStripeBillingPortalSessionRead(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceStripeBillingPortalSessionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.BillingPortalSessionParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+
+	if configuration, set := d.GetOk("configuration"); set {
+		params.Configuration = stripe.String(ToString(configuration))
+	}
+	if returnURL, set := d.GetOk("return_url"); set {
+		params.ReturnURL = stripe.String(ToString(returnURL))
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	session, err := c.BillingPortalSessions.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(session.ID)
+	return CallSet(
+		func() error {
+			if session.Configuration != nil {
+				return d.Set("configuration", session.Configuration.ID)
+			}
+			return nil
+		}(),
+		d.Set("url", session.URL),
+		d.Set("created", session.Created),
+	)
+}
+
+func resourceStripeBillingPortalSessionDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// billing portal sessions expire on their own and can't be revoked through the API
+	d.SetId("")
+	return nil
+}
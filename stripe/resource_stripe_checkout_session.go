@@ -0,0 +1,210 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeCheckoutSession() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCheckoutSessionRead,
+		CreateContext: resourceStripeCheckoutSessionCreate,
+		DeleteContext: resourceStripeCheckoutSessionDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"mode": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.CheckoutSessionModePayment),
+					string(stripe.CheckoutSessionModeSetup),
+					string(stripe.CheckoutSessionModeSubscription),
+				}, false),
+				Description: "The mode of the Checkout Session: payment, setup or subscription.",
+			},
+			"line_items": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Description: "A list of items the customer is purchasing. " +
+					"Required in payment and subscription mode.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"price": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The ID of the Price object.",
+						},
+						"quantity": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     1,
+							Description: "The quantity of the line item being purchased.",
+						},
+					},
+				},
+			},
+			"success_url": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The URL to which Stripe should send customers " +
+					"when payment or setup is complete.",
+			},
+			"cancel_url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The URL the customer will be directed to if they decide to cancel payment.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of an existing customer, if one exists.",
+			},
+			"payment_method_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of the types of payment methods (e.g., card) this Checkout Session can accept.",
+			},
+			"allow_promotion_codes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Enables user redeemable promotion codes.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL to the Checkout Session.",
+			},
+			"payment_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The payment status of the Checkout Session, one of paid, unpaid, " +
+					"or no_payment_required.",
+			},
+		},
+	}
+}
+
+func resourceStripeCheckoutSessionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	session, err := c.CheckoutSessions.Get(d.Id(), &stripe.CheckoutSessionParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("mode", session.Mode),
+		d.Set("success_url", session.SuccessURL),
+		d.Set("cancel_url", session.CancelURL),
+		d.Set("customer", func() string {
+			if session.Customer != nil {
+				return session.Customer.ID
+			}
+			return ""
+		}()),
+		d.Set("payment_method_types", session.PaymentMethodTypes),
+		d.Set("allow_promotion_codes", session.AllowPromotionCodes),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(session.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("url", session.URL),
+		d.Set("payment_status", session.PaymentStatus),
+	)
+}
+
+func resourceStripeCheckoutSessionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.CheckoutSessionParams{
+		Mode:       stripe.String(ExtractString(d, "mode")),
+		SuccessURL: stripe.String(ExtractString(d, "success_url")),
+		CancelURL:  stripe.String(ExtractString(d, "cancel_url")),
+	}
+
+	if lineItems, set := d.GetOk("line_items"); set {
+		for _, item := range ToSlice(lineItems) {
+			lineItem := &stripe.CheckoutSessionLineItemParams{}
+			for k, v := range ToMap(item) {
+				switch k {
+				case "price":
+					lineItem.Price = stripe.String(ToString(v))
+				case "quantity":
+					lineItem.Quantity = stripe.Int64(ToInt64(v))
+				}
+			}
+			params.LineItems = append(params.LineItems, lineItem)
+		}
+	}
+	if customer, set := d.GetOk("customer"); set {
+		params.Customer = stripe.String(ToString(customer))
+	}
+	if paymentMethodTypes, set := d.GetOk("payment_method_types"); set {
+		params.PaymentMethodTypes = stripe.StringSlice(ToStringSlice(paymentMethodTypes))
+	}
+	if allowPromotionCodes, set := d.GetOk("allow_promotion_codes"); set {
+		params.AllowPromotionCodes = stripe.Bool(ToBool(allowPromotionCodes))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	session, err := c.CheckoutSessions.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(session.ID)
+	return resourceStripeCheckoutSessionRead(ctx, d, m)
+}
+
+func resourceStripeCheckoutSessionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// the pinned Stripe SDK has no wrapper for the expire endpoint yet, so call it through the backend
+	session := &stripe.CheckoutSession{}
+	path := stripe.FormatURLPath("/v1/checkout/sessions/%s/expire", d.Id())
+	err := rawCall(m, http.MethodPost, path, &stripe.Params{Context: ctx}, session)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		// sessions which are already complete or expired can't be expired again
+		if stripeErr, ok := err.(*stripe.Error); ok && stripeErr.HTTPStatusCode == http.StatusBadRequest {
+			log.Printf("[WARN] Checkout Session %s can't be expired: %s", d.Id(), stripeErr.Msg)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,375 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeConnectAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeConnectAccountRead,
+		CreateContext: resourceStripeConnectAccountCreate,
+		UpdateContext: resourceStripeConnectAccountUpdate,
+		DeleteContext: resourceStripeConnectAccountDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.AccountTypeCustom),
+					string(stripe.AccountTypeExpress),
+					string(stripe.AccountTypeStandard),
+				}, false),
+				Description: "The type of Stripe account to create, one of standard, express, or custom.",
+			},
+			"country": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The country in which the account holder resides, or in which the business is legally established. " +
+					"Defaults to the country of the platform account.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The email address of the account holder.",
+			},
+			"business_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.AccountBusinessTypeCompany),
+					string(stripe.AccountBusinessTypeGovernmentEntity),
+					string(stripe.AccountBusinessTypeIndividual),
+					string(stripe.AccountBusinessTypeNonProfit),
+				}, false),
+				Description: "The business type, one of individual, company, non_profit or government_entity.",
+			},
+			"capabilities": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Capabilities requested for the account. " +
+					"Each capability is only requested, Stripe decides when it becomes active.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"card_payments": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Request the card payments capability.",
+						},
+						"transfers": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Request the transfers capability.",
+						},
+						"card_issuing": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Request the card issuing capability.",
+						},
+						"tax_reporting_us_1099_k": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Request the tax reporting 1099-K capability.",
+						},
+						"tax_reporting_us_1099_misc": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Request the tax reporting 1099-MISC capability.",
+						},
+					},
+				},
+			},
+			"business_profile": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Description: "Business information about the account. " +
+					"Values filled in by the account holder during onboarding are exported as well.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The customer-facing business name.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The business’s publicly available website.",
+						},
+						"mcc": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The merchant category code for the account.",
+						},
+						"product_description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Internal-only description of the product sold or service provided by the business.",
+						},
+						"support_email": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "A publicly available email address for sending support issues to.",
+						},
+						"support_phone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "A publicly available phone number to call with support issues.",
+						},
+						"support_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "A publicly available website for handling support issues.",
+						},
+					},
+				},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"charges_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account can create live charges.",
+			},
+			"payouts_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether Stripe can send payouts to this account.",
+			},
+			"details_submitted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether account details have been submitted.",
+			},
+		},
+	}
+}
+
+func resourceStripeConnectAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	account, err := c.Account.GetByID(d.Id(), &stripe.AccountParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("type", account.Type),
+		d.Set("country", account.Country),
+		d.Set("email", account.Email),
+		d.Set("business_type", account.BusinessType),
+		func() error {
+			if account.BusinessProfile == nil {
+				return d.Set("business_profile", nil)
+			}
+			return d.Set("business_profile", []interface{}{
+				map[string]interface{}{
+					"name":                account.BusinessProfile.Name,
+					"url":                 account.BusinessProfile.URL,
+					"mcc":                 account.BusinessProfile.MCC,
+					"product_description": account.BusinessProfile.ProductDescription,
+					"support_email":       account.BusinessProfile.SupportEmail,
+					"support_phone":       account.BusinessProfile.SupportPhone,
+					"support_url":         account.BusinessProfile.SupportURL,
+				},
+			})
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(account.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("charges_enabled", account.ChargesEnabled),
+		d.Set("payouts_enabled", account.PayoutsEnabled),
+		d.Set("details_submitted", account.DetailsSubmitted),
+	)
+}
+
+func resourceStripeConnectAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.AccountParams{
+		Type: stripe.String(ExtractString(d, "type")),
+	}
+
+	if country, set := d.GetOk("country"); set {
+		params.Country = stripe.String(ToString(country))
+	}
+	if email, set := d.GetOk("email"); set {
+		params.Email = stripe.String(ToString(email))
+	}
+	if businessType, set := d.GetOk("business_type"); set {
+		params.BusinessType = stripe.String(ToString(businessType))
+	}
+	if capabilities, set := d.GetOk("capabilities"); set {
+		params.Capabilities = expandConnectAccountCapabilities(nil, capabilities)
+	}
+	if businessProfile, set := d.GetOk("business_profile"); set {
+		params.BusinessProfile = expandConnectAccountBusinessProfile(businessProfile)
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	params.Context = ctx
+	setIdempotencyKey(d, &params.Params)
+	account, err := c.Account.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(account.ID)
+	return resourceStripeConnectAccountRead(ctx, d, m)
+}
+
+func resourceStripeConnectAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.AccountParams{}
+
+	// once a standard account is onboarded its details belong to the account holder,
+	// the platform may only change metadata and request capabilities
+	if stripe.AccountType(ExtractString(d, "type")) == stripe.AccountTypeStandard && ExtractBool(d, "details_submitted") {
+		for _, key := range []string{"email", "business_type", "business_profile"} {
+			if d.HasChange(key) {
+				return diag.Errorf("%s can't be changed, the standard account %s completed onboarding "+
+					"and is managed by the account holder", key, d.Id())
+			}
+		}
+	}
+
+	if d.HasChange("email") {
+		params.Email = stripe.String(ExtractString(d, "email"))
+	}
+	if d.HasChange("business_type") {
+		params.BusinessType = stripe.String(ExtractString(d, "business_type"))
+	}
+	if d.HasChange("capabilities") {
+		oldCapabilities, newCapabilities := d.GetChange("capabilities")
+		params.Capabilities = expandConnectAccountCapabilities(oldCapabilities, newCapabilities)
+	}
+	if d.HasChange("business_profile") {
+		params.BusinessProfile = expandConnectAccountBusinessProfile(d.Get("business_profile"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Account.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeConnectAccountRead(ctx, d, m)
+}
+
+func resourceStripeConnectAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	_, err := c.Account.Del(d.Id(), &stripe.AccountParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandConnectAccountCapabilities only sends capabilities which differ from the previous configuration,
+// Stripe rejects unrequesting capabilities an account type always has, e.g. card_payments of standard accounts.
+func expandConnectAccountCapabilities(oldValue, newValue interface{}) *stripe.AccountCapabilitiesParams {
+	oldCapabilities := ToMap(oldValue)
+	capabilities := &stripe.AccountCapabilitiesParams{}
+	for k, v := range ToMap(newValue) {
+		requested := ToBool(v)
+		if requested == ToBool(oldCapabilities[k]) {
+			continue
+		}
+		switch k {
+		case "card_payments":
+			capabilities.CardPayments = &stripe.AccountCapabilitiesCardPaymentsParams{
+				Requested: stripe.Bool(requested),
+			}
+		case "transfers":
+			capabilities.Transfers = &stripe.AccountCapabilitiesTransfersParams{
+				Requested: stripe.Bool(requested),
+			}
+		case "card_issuing":
+			capabilities.CardIssuing = &stripe.AccountCapabilitiesCardIssuingParams{
+				Requested: stripe.Bool(requested),
+			}
+		case "tax_reporting_us_1099_k":
+			capabilities.TaxReportingUS1099K = &stripe.AccountCapabilitiesTaxReportingUS1099KParams{
+				Requested: stripe.Bool(requested),
+			}
+		case "tax_reporting_us_1099_misc":
+			capabilities.TaxReportingUS1099MISC = &stripe.AccountCapabilitiesTaxReportingUS1099MISCParams{
+				Requested: stripe.Bool(requested),
+			}
+		}
+	}
+	return capabilities
+}
+
+func expandConnectAccountBusinessProfile(value interface{}) *stripe.AccountBusinessProfileParams {
+	businessProfile := &stripe.AccountBusinessProfileParams{}
+	for k, v := range ToMap(value) {
+		value := ToString(v)
+		if value == "" {
+			continue
+		}
+		switch k {
+		case "name":
+			businessProfile.Name = stripe.String(value)
+		case "url":
+			businessProfile.URL = stripe.String(value)
+		case "mcc":
+			businessProfile.MCC = stripe.String(value)
+		case "product_description":
+			businessProfile.ProductDescription = stripe.String(value)
+		case "support_email":
+			businessProfile.SupportEmail = stripe.String(value)
+		case "support_phone":
+			businessProfile.SupportPhone = stripe.String(value)
+		case "support_url":
+			businessProfile.SupportURL = stripe.String(value)
+		}
+	}
+	return businessProfile
+}
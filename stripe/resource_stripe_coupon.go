@@ -2,11 +2,18 @@ package stripe
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v72"
 	"github.com/stripe/stripe-go/v72/client"
 )
@@ -17,12 +24,32 @@ func resourceStripeCoupon() *schema.Resource {
 		CreateContext: resourceStripeCouponCreate,
 		UpdateContext: resourceStripeCouponUpdate,
 		DeleteContext: resourceStripeCouponDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStripeCouponImport,
+		},
+		CustomizeDiff: customdiff.All(
+			resourceStripeCouponCustomizeDiffDiscount,
+			resourceStripeCouponCustomizeDiffAmountOff,
+			resourceStripeCouponCustomizeDiffDurationInMonths,
+			resourceStripeCouponCustomizeDiffRedeemBy,
+		),
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Unique identifier for the object.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "Unique identifier for the object. " +
+					"Stripe generates one when not set, a custom ID like LAUNCH2024 can be used as the coupon code. " +
+					"Changing it replaces the coupon.",
 			},
+			"idempotency_key": idempotencyKeySchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -34,24 +61,50 @@ func resourceStripeCoupon() *schema.Resource {
 				ForceNew:      true,
 				ConflictsWith: []string{"percent_off"},
 				Description: "Amount (in the currency specified) that will be taken off the subtotal of any invoices " +
-					"for this customer.",
+					"for this customer. Changing it replaces the coupon.",
 			},
 			"currency": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  nil,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Default:      nil,
 				Description: "If amount_off has been set, " +
-					"the three-letter ISO code for the currency of the amount to take off.",
+					"the three-letter ISO code for the currency of the amount to take off. Changing it replaces the coupon.",
+			},
+			"currency_options": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"amount_off"},
+				Description:  "Amounts to take off in currencies other than the coupon's currency. Changing them replaces the coupon.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCurrency,
+							Description:  "Three-letter ISO currency code, in lowercase.",
+						},
+						"amount_off": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Amount, in the smallest unit of the currency, taken off the subtotal.",
+						},
+					},
+				},
 			},
 			"percent_off": {
 				Type:          schema.TypeFloat,
 				Optional:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"amount_off", "currency"},
+				ValidateFunc:  validation.FloatBetween(0, 100),
 				Description: "Percent that will be taken off the subtotal of any invoices for this customer " +
 					"for the duration of the coupon. " +
-					"For example, a coupon with percent_off of 50 will make a $100 invoice $50 instead.",
+					"For example, a coupon with percent_off of 50 will make a $100 invoice $50 instead. " +
+					"Changing it replaces the coupon.",
 			},
 			"duration": {
 				Type:     schema.TypeString,
@@ -59,14 +112,15 @@ func resourceStripeCoupon() *schema.Resource {
 				ForceNew: true,
 				Default:  "once",
 				Description: "One of forever, once, and repeating. " +
-					"Describes how long a customer who applies this coupon will get the discount.",
+					"Describes how long a customer who applies this coupon will get the discount. " +
+					"Changing it replaces the coupon.",
 			},
 			"duration_in_months": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				ForceNew: true,
 				Description: "If duration is repeating, the number of months the coupon applies. " +
-					"Null if coupon duration is forever or once.",
+					"Null if coupon duration is forever or once. Changing it replaces the coupon.",
 			},
 			"max_redemptions": {
 				Type:     schema.TypeInt,
@@ -74,13 +128,17 @@ func resourceStripeCoupon() *schema.Resource {
 				ForceNew: true,
 				Default:  nil,
 				Description: "Maximum number of times this coupon can be redeemed, " +
-					"in total, across all customers, before it is no longer valid.",
+					"in total, across all customers, before it is no longer valid. Changing it replaces the coupon.",
 			},
 			"redeem_by": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Date after which the coupon can no longer be redeemed. Expected format is RFC3339",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateTimestamp,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+				Description: "Date after which the coupon can no longer be redeemed. " +
+					"Expected format is RFC3339 or Unix epoch seconds, it's stored in the RFC3339 format. " +
+					"Changing it replaces the coupon.",
 			},
 			"times_redeemed": {
 				Type:        schema.TypeInt,
@@ -92,7 +150,14 @@ func resourceStripeCoupon() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "A list of product IDs this coupon applies to",
+				Description: "A list of product IDs this coupon applies to. Changing it replaces the coupon.",
+			},
+			"applies_to_product_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the products this coupon applies to. " +
+					"Products which can't be retrieved anymore are left out.",
 			},
 			"metadata": {
 				Type:     schema.TypeMap,
@@ -107,15 +172,91 @@ func resourceStripeCoupon() *schema.Resource {
 				Description: "Taking account of the above properties, " +
 					"whether this coupon can still be applied to a customer.",
 			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Has the value true if the object exists in live mode " +
+					"or the value false if the object exists in test mode.",
+			},
+			"object": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "String representing the object’s type.",
+			},
 		},
 	}
 }
 
+// resourceStripeCouponCustomizeDiffDiscount catches coupons without a discount at plan time,
+// Stripe rejects them on create but terraform would only find out during the apply.
+func resourceStripeCouponCustomizeDiffDiscount(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("amount_off") || !d.NewValueKnown("percent_off") {
+		return nil
+	}
+
+	_, amountOffSet := d.GetOk("amount_off")
+	_, percentOffSet := d.GetOk("percent_off")
+	if !amountOffSet && !percentOffSet {
+		return errors.New("one of amount_off or percent_off has to be set")
+	}
+	return nil
+}
+
+func resourceStripeCouponCustomizeDiffAmountOff(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("amount_off") || !d.NewValueKnown("currency") {
+		return nil
+	}
+
+	_, amountOffSet := d.GetOk("amount_off")
+	_, currencySet := d.GetOk("currency")
+	switch {
+	case currencySet && !amountOffSet:
+		return errors.New("currency can only be set together with amount_off")
+	case amountOffSet && !currencySet:
+		return errors.New("currency is required when amount_off is set")
+	}
+	return nil
+}
+
+func resourceStripeCouponCustomizeDiffDurationInMonths(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("duration") || !d.NewValueKnown("duration_in_months") {
+		return nil
+	}
+
+	if _, set := d.GetOk("duration_in_months"); set && d.Get("duration").(string) != "repeating" {
+		return errors.New("duration_in_months can only be set when duration is repeating")
+	}
+	return nil
+}
+
+// resourceStripeCouponCustomizeDiffRedeemBy only looks at a changed redeem_by,
+// coupons which already passed their redemption date must keep planning cleanly.
+func resourceStripeCouponCustomizeDiffRedeemBy(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("redeem_by") || !d.NewValueKnown("redeem_by") {
+		return nil
+	}
+
+	redeemByStr := ToString(d.Get("redeem_by"))
+	if redeemByStr == "" {
+		return nil
+	}
+	redeemBy, err := parseTimestamp(redeemByStr)
+	if err != nil {
+		return err
+	}
+	if !time.Unix(redeemBy, 0).After(time.Now()) {
+		return fmt.Errorf("redeem_by \"%s\" is in the past, Stripe only accepts a future redemption date", redeemByStr)
+	}
+	return nil
+}
+
 func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.CouponParams{}
-	couponDuration := d.Get("duration").(string)
 
+	if id, set := d.GetOk("id"); set {
+		params.ID = stripe.String(ToString(id))
+	}
 	if name, set := d.GetOk("name"); set {
 		params.Name = stripe.String(ToString(name))
 	}
@@ -123,127 +264,245 @@ func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m i
 		params.AmountOff = stripe.Int64(ToInt64(amountOff))
 	}
 	if currency, set := d.GetOk("currency"); set {
-		if &params.AmountOff == nil {
-			return diag.Errorf("can't set currency when using percent off")
-		}
 		params.Currency = stripe.String(currency.(string))
 	}
+	if currencyOptions, set := d.GetOk("currency_options"); set {
+		// stripe-go v72 has no field for currency_options, they are sent as extra form values
+		for _, option := range currencyOptions.(*schema.Set).List() {
+			optionMap := ToMap(option)
+			params.AddExtra(fmt.Sprintf("currency_options[%s][amount_off]", ToString(optionMap["currency"])),
+				strconv.FormatInt(ToInt64(optionMap["amount_off"]), 10))
+		}
+	}
 	if percentOff, set := d.GetOk("percent_off"); set {
 		params.PercentOff = stripe.Float64(ToFloat64(percentOff))
 	}
 	if duration, set := d.GetOk("duration"); set {
 		params.Duration = stripe.String(ToString(duration))
 	}
+	// resourceStripeCouponCustomizeDiffDurationInMonths rejects duration_in_months without a repeating duration
 	if durationInMonths, set := d.GetOk("duration_in_months"); set {
-		if couponDuration != "repeating" {
-			return diag.Errorf("can't set duration in months if event is not repeating")
-		}
 		params.DurationInMonths = stripe.Int64(ToInt64(durationInMonths))
 	}
 	if maxRedemptions, set := d.GetOk("max_redemptions"); set {
 		params.MaxRedemptions = stripe.Int64(ToInt64(maxRedemptions))
 	}
 	if redeemByStr, set := d.GetOk("redeem_by"); set {
-		redeemByTime, err := time.Parse(time.RFC3339, redeemByStr.(string))
+		redeemBy, err := parseTimestamp(ToString(redeemByStr))
 
 		if err != nil {
-			return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", redeemByStr)
+			return attributeErrorf("redeem_by", "%s", err)
 		}
 
-		params.RedeemBy = stripe.Int64(redeemByTime.Unix())
+		params.RedeemBy = stripe.Int64(redeemBy)
 	}
 	if appliesTo, set := d.GetOk("applies_to"); set {
 		params.AppliesTo = &stripe.CouponAppliesToParams{
 			Products: stripe.StringSlice(ToStringSlice(appliesTo)),
 		}
 	}
-	if meta, set := d.GetOk("metadata"); set {
-		for k, v := range ToMap(meta) {
-			params.AddMetadata(k, ToString(v))
-		}
-	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	params.Context = ctx
 
+	setIdempotencyKey(d, &params.Params)
 	coupon, err := c.Coupons.New(params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromStripeErr(err)
 	}
 
 	log.Printf("[INFO] Create coupon: %s (%s)", coupon.Name, coupon.ID)
 	d.SetId(coupon.ID)
-	d.Set("valid", coupon.Valid)
-	d.Set("times_redeemed", coupon.TimesRedeemed)
+	dg := CallSet(
+		d.Set("valid", coupon.Valid),
+		d.Set("times_redeemed", coupon.TimesRedeemed),
+	)
+	if len(dg) > 0 {
+		return dg
+	}
 
 	return resourceStripeCouponRead(ctx, d, m)
 }
 
-func resourceStripeCouponRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripeCouponImport accepts name=<coupon_name> besides the coupon ID,
+// for coupons whose generated ID isn't at hand when migrating them to Terraform.
+// It reads the coupon itself, the state has nothing but the ID so far and applies_to is unknown.
+func resourceStripeCouponImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if name := strings.TrimPrefix(d.Id(), "name="); name != d.Id() {
+		coupon, err := findCouponByName(ctx, m.(*Config).API, name)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(coupon.ID)
+	}
+
+	id := d.Id()
+	if diags := readCoupon(ctx, d, m, true); diags.HasError() {
+		return nil, fmt.Errorf("can't read coupon %s: %s", id, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("no coupon found with ID %q", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// findCouponByName looks up the only coupon with the given name, coupon names aren't unique
+// so several matches are an error as much as none.
+func findCouponByName(ctx context.Context, c *client.API, name string) (*stripe.Coupon, error) {
+	// Stripe can't filter coupons by name, so every coupon has to be looked at
+	params := &stripe.CouponListParams{}
+	params.Context = ctx
+	params.Limit = stripe.Int64(100)
+
+	var coupons []*stripe.Coupon
+	it := c.Coupons.List(params)
+	for len(coupons) < 2 && it.Next() {
+		if it.Coupon().Name == name {
+			coupons = append(coupons, it.Coupon())
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	switch len(coupons) {
+	case 0:
+		return nil, fmt.Errorf("no coupon found with name %q", name)
+	case 1:
+		return coupons[0], nil
+	default:
+		return nil, fmt.Errorf("multiple coupons found with name %q, use the coupon ID instead", name)
+	}
+}
+
+func resourceStripeCouponRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return readCoupon(ctx, d, m, false)
+}
+
+// readCoupon refreshes the coupon, imported tells the read of resourceStripeCouponImport apart.
+// An import expands everything, also with minimal_reads, applies_to and currency_options are ForceNew.
+func readCoupon(ctx context.Context, d *schema.ResourceData, m interface{}, imported bool) diag.Diagnostics {
+	c := m.(*Config).API
 
 	params := &stripe.CouponParams{}
-	params.AddExpand("applies_to")
+	params.Context = ctx
+	if _, set := d.GetOk("applies_to"); set || imported {
+		params.AddExpand("applies_to")
+	}
+	if imported || m.(*Config).expandOnRead(d, "currency_options") {
+		params.AddExpand("currency_options")
+	}
 
 	coupon, err := c.Coupons.Get(d.Id(), params)
 	if err != nil {
-		return diag.FromErr(err)
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diagFromStripeErr(err)
+	}
+
+	// the Coupon type of stripe-go v72 drops currency_options, they are decoded from the response body
+	var couponCurrencyOptions struct {
+		CurrencyOptions map[string]struct {
+			AmountOff int64 `json:"amount_off"`
+		} `json:"currency_options"`
+	}
+	if coupon.LastResponse != nil {
+		if err := json.Unmarshal(coupon.LastResponse.RawJSON, &couponCurrencyOptions); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	var currencyOptions []interface{}
+	for currency, option := range couponCurrencyOptions.CurrencyOptions {
+		if currency == string(coupon.Currency) {
+			continue
+		}
+		currencyOptions = append(currencyOptions, map[string]interface{}{
+			"currency":   currency,
+			"amount_off": option.AmountOff,
+		})
 	}
 
 	var appliesTo []string
 	if coupon.AppliesTo != nil {
-		appliesTo = coupon.AppliesTo.Products
+		// applies_to is ForceNew, a reordering alone would otherwise recreate the coupon
+		appliesTo = keepConfiguredOrder(coupon.AppliesTo.Products, ExtractStringSlice(d, "applies_to"))
 	}
 
-	var RedeemByStr string
-	if coupon.RedeemBy != 0 {
-		RedeemByStr = time.Unix(coupon.RedeemBy, 0).Format(time.RFC3339)
+	// resolving the names is best-effort, a deleted product must not break the coupon refresh
+	var appliesToProductNames []string
+	for _, productID := range appliesTo {
+		product, err := c.Products.Get(productID, &stripe.ProductParams{Params: stripe.Params{Context: ctx}})
+		if err != nil {
+			log.Printf("[WARN] Can't read product %s the coupon %s applies to: %s", productID, coupon.ID, err)
+			continue
+		}
+		appliesToProductNames = append(appliesToProductNames, product.Name)
 	}
 
 	return CallSet(
 		d.Set("name", coupon.Name),
 		d.Set("amount_off", coupon.AmountOff),
 		d.Set("currency", coupon.Currency),
+		d.Set("currency_options", currencyOptions),
 		d.Set("percent_off", coupon.PercentOff),
 		d.Set("duration", coupon.Duration),
 		d.Set("duration_in_months", coupon.DurationInMonths),
 		d.Set("max_redemptions", coupon.MaxRedemptions),
-		d.Set("redeem_by", RedeemByStr),
+		d.Set("redeem_by", ToRFC3339(coupon.RedeemBy)),
 		d.Set("times_redeemed", coupon.TimesRedeemed),
 		d.Set("applies_to", appliesTo),
-		d.Set("metadata", coupon.Metadata),
+		d.Set("applies_to_product_names", appliesToProductNames),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(coupon.Metadata, ExtractMap(d, "metadata"))),
 		d.Set("valid", coupon.Valid),
+		d.Set("livemode", coupon.Livemode),
+		d.Set("object", coupon.Object),
 	)
 }
 
 func resourceStripeCouponUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.CouponParams{}
 
+	// changes of the timeouts alone have nothing to send to Stripe
+	if !d.HasChanges("name", "metadata") {
+		return resourceStripeCouponRead(ctx, d, m)
+	}
+
 	if d.HasChange("name") {
 		params.Name = stripe.String(ExtractString(d, "name"))
 	}
 	if d.HasChange("metadata") {
-		params.Metadata = nil
-		metadata := ExtractMap(d, "metadata")
-		for k, v := range metadata {
-			params.AddMetadata(k, ToString(v))
-		}
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
 	}
 
+	params.Context = ctx
 	_, err := c.Coupons.Update(d.Id(), params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromStripeErr(err)
 	}
 
 	return resourceStripeCouponRead(ctx, d, m)
 }
 
-func resourceStripeCouponDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripeCouponDelete warns when the coupon had been redeemed, also when it's deleted to be replaced.
+// The SDK doesn't let a CustomizeDiff return warnings, so replacing a coupon can't be called out at plan time.
+func resourceStripeCouponDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 
-	_, err := c.Coupons.Del(d.Id(), nil)
+	_, err := c.Coupons.Del(d.Id(), &stripe.CouponParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromStripeErr(err)
 	}
 
+	var diags diag.Diagnostics
+	if timesRedeemed := ExtractInt(d, "times_redeemed"); timesRedeemed > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Deleted coupon %s had been redeemed %d times", d.Id(), timesRedeemed),
+			Detail: "Its redemption history is lost. When the coupon is replaced, e.g. because currency or " +
+				"amount_off changed, the new coupon starts over with a times_redeemed of 0.",
+		})
+	}
 	d.SetId("")
-	return nil
+	return diags
 }
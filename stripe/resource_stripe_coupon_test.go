@@ -0,0 +1,1042 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeCoupon_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckStripeCouponDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCouponConfig("Launch discount"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_coupon.test", "name", "Launch discount"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "percent_off", "25"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "duration", "once"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "valid", "true"),
+				),
+			},
+			{
+				Config: testAccStripeCouponConfig("Launch discount, renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_coupon.test", "name", "Launch discount, renamed"),
+				),
+			},
+			{
+				ResourceName:      "stripe_coupon.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the key of the create isn't known to an import
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccStripeCoupon_importByName(t *testing.T) {
+	name := "Import by name " + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckStripeCouponDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCouponConfig(name),
+			},
+			{
+				ResourceName:      "stripe_coupon.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the key of the create isn't known to an import
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				ResourceName:      "stripe_coupon.test",
+				ImportState:       true,
+				ImportStateId:     "name=" + name,
+				ImportStateVerify: true,
+				// the key of the create isn't known to an import
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				ResourceName:  "stripe_coupon.test",
+				ImportState:   true,
+				ImportStateId: "name=" + name + " (missing)",
+				ExpectError:   regexp.MustCompile(`no coupon found with name`),
+			},
+		},
+	})
+}
+
+// TestStripeMockCoupon_lifecycle creates, reads and deletes a coupon through the provider configured with
+// api_base_url, against a stripe-mock instance at STRIPE_MOCK_URL, e.g. http://localhost:12111.
+// stripe-mock answers with fixtures, so only the requests succeeding and the ID are checked.
+func TestStripeMockCoupon_lifecycle(t *testing.T) {
+	url := os.Getenv("STRIPE_MOCK_URL")
+	if url == "" {
+		t.Skip("STRIPE_MOCK_URL must be set to run against stripe-mock")
+	}
+
+	config := testProviderConfig(t, map[string]interface{}{
+		"api_key":      "sk_test_123",
+		"api_base_url": url,
+	})
+	state := testApplyCoupon(t, config, nil, map[string]interface{}{
+		"duration":    "once",
+		"percent_off": 25,
+	})
+	if state == nil || state.ID == "" {
+		t.Fatalf("expected the created coupon in the state, got %v", state)
+	}
+
+	r := resourceStripeCoupon()
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if refreshed == nil || refreshed.ID != state.ID {
+		t.Fatalf("expected the coupon %s to be read back, got %v", state.ID, refreshed)
+	}
+
+	destroyed, diags := r.Apply(context.Background(), refreshed, &terraform.InstanceDiff{Destroy: true}, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if destroyed != nil {
+		t.Errorf("expected no state after the delete, got %v", destroyed)
+	}
+}
+
+// TestResourceStripeCouponRead_minimalReads checks the expands sent when refreshing a coupon,
+// against a local server standing in for Stripe.
+func TestResourceStripeCouponRead_minimalReads(t *testing.T) {
+	var expands []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		expands = nil
+		for key, values := range r.URL.Query() {
+			if strings.HasPrefix(key, "expand") {
+				expands = append(expands, values...)
+			}
+		}
+		sort.Strings(expands)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25, "valid": true}`)
+	})
+
+	// a coupon read before has applies_to and currency_options in the state, empty or not
+	read := map[string]string{"id": "test", "duration": "once", "applies_to.#": "0", "currency_options.#": "0"}
+	cases := []struct {
+		name         string
+		minimalReads bool
+		state        map[string]string
+		expands      []string
+	}{
+		{"default", false, read, []string{"currency_options"}},
+		{"default import", false, nil, []string{"applies_to", "currency_options"}},
+		{"minimal", true, read, nil},
+		{"minimal never read", true, map[string]string{"id": "test", "duration": "once"}, []string{"currency_options"}},
+		{"minimal import", true, nil, []string{"applies_to", "currency_options"}},
+		{"minimal with applies_to", true, map[string]string{
+			"id":                 "test",
+			"duration":           "once",
+			"applies_to.#":       "1",
+			"applies_to.0":       "prod_123",
+			"currency_options.#": "0",
+		}, []string{"applies_to"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &Config{API: api, MinimalReads: tc.minimalReads}
+			if tc.state == nil {
+				// an imported coupon has nothing but its ID, not even the schema defaults
+				d := resourceStripeCoupon().Data(nil)
+				d.SetId("test")
+				if _, err := resourceStripeCouponImport(context.Background(), d, config); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else {
+				d := resourceStripeCoupon().Data(&terraform.InstanceState{ID: "test", Attributes: tc.state})
+				if diags := resourceStripeCouponRead(context.Background(), d, config); diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+			}
+			if !reflect.DeepEqual(expands, tc.expands) {
+				t.Errorf("expected expands %v, got %v", tc.expands, expands)
+			}
+		})
+	}
+}
+
+func TestResourceStripeCouponRead_ignoreMetadataKeys(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25, "valid": true,
+			"metadata": {"team": "growth", "added_by_integration": "true"}}`)
+	})
+
+	cases := []struct {
+		name     string
+		ignored  map[string]bool
+		metadata map[string]interface{}
+	}{
+		{"not ignored", nil, map[string]interface{}{"team": "growth", "added_by_integration": "true"}},
+		{"ignored", map[string]bool{"added_by_integration": true}, map[string]interface{}{"team": "growth"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+				"percent_off": 25,
+				"metadata":    map[string]interface{}{"team": "growth"},
+			})
+			d.SetId("test")
+			diags := resourceStripeCouponRead(context.Background(), d, &Config{API: api, IgnoreMetadataKeys: tc.ignored})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if metadata := d.Get("metadata"); !reflect.DeepEqual(metadata, tc.metadata) {
+				t.Errorf("expected metadata %v, got %v", tc.metadata, metadata)
+			}
+		})
+	}
+}
+
+func TestResourceStripeCouponRead_livemodeObject(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "livemode": true, "duration": "once", "percent_off": 25}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{"percent_off": 25})
+	d.SetId("test")
+	if diags := resourceStripeCouponRead(context.Background(), d, &Config{API: api}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !ExtractBool(d, "livemode") {
+		t.Errorf("expected livemode true")
+	}
+	if object := ExtractString(d, "object"); object != "coupon" {
+		t.Errorf("expected object coupon, got %q", object)
+	}
+}
+
+// TestResourceStripeCouponRead_appliesToProductNames resolves the names of the products a coupon applies to,
+// a product which was deleted since is left out without failing the refresh.
+func TestResourceStripeCouponRead_appliesToProductNames(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/coupons/test":
+			fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25,
+				"applies_to": {"products": ["prod_pro", "prod_deleted"]}}`)
+		case "/v1/products/prod_pro":
+			fmt.Fprint(w, `{"id": "prod_pro", "object": "product", "name": "Pro plan"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "code": "resource_missing", "message": "No such product"}}`)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+		"percent_off": 25,
+		"applies_to":  []interface{}{"prod_pro", "prod_deleted"},
+	})
+	d.SetId("test")
+	if diags := resourceStripeCouponRead(context.Background(), d, &Config{API: api}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := []string{"Pro plan"}
+	if names := ExtractStringSlice(d, "applies_to_product_names"); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected product names %v, got %v", expected, names)
+	}
+}
+
+// TestResourceStripeCouponRead_appliesToOrder refreshes a coupon Stripe returns the products of in another order,
+// the configured order is kept so that applies_to doesn't plan a replacement.
+func TestResourceStripeCouponRead_appliesToOrder(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v1/coupons/test" {
+			fmt.Fprint(w, `{"id": "prod", "object": "product", "name": "Product"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25,
+			"applies_to": {"products": ["prod_b", "prod_a"]}}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+		"percent_off": 25,
+		"applies_to":  []interface{}{"prod_a", "prod_b"},
+	})
+	d.SetId("test")
+	if diags := resourceStripeCouponRead(context.Background(), d, &Config{API: api}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := []string{"prod_a", "prod_b"}
+	if appliesTo := ExtractStringSlice(d, "applies_to"); !reflect.DeepEqual(appliesTo, expected) {
+		t.Errorf("expected applies_to %v, got %v", expected, appliesTo)
+	}
+}
+
+// TestResourceStripeCouponRead_currencyOptions reads single and two currency coupons back,
+// the coupon's own currency never shows up among the currency_options.
+func TestResourceStripeCouponRead_currencyOptions(t *testing.T) {
+	cases := []struct {
+		name            string
+		currencyOptions string
+		expected        []interface{}
+	}{
+		{"single currency", `{"usd": {"amount_off": 1000}}`, []interface{}{}},
+		{"two currencies", `{"usd": {"amount_off": 1000}, "eur": {"amount_off": 900}}`,
+			[]interface{}{map[string]interface{}{"currency": "eur", "amount_off": 900}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id": "test", "object": "coupon", "duration": "once", "amount_off": 1000, "currency": "usd",
+					"currency_options": %s}`, tc.currencyOptions)
+			})
+			d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+				"amount_off": 1000,
+				"currency":   "usd",
+			})
+			d.SetId("test")
+			if diags := resourceStripeCouponRead(context.Background(), d, &Config{API: api}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if options := d.Get("currency_options").(*schema.Set).List(); !reflect.DeepEqual(options, tc.expected) {
+				t.Errorf("expected currency_options %v, got %v", tc.expected, options)
+			}
+		})
+	}
+
+	diags := resourceStripeCoupon().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"amount_off": 1000,
+		"currency":   "usd",
+		"currency_options": []interface{}{
+			map[string]interface{}{"currency": "xyz", "amount_off": 900},
+		},
+	}))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary+diags[0].Detail, "ISO 4217") {
+		t.Errorf("expected an unknown currency error, got %v", diags)
+	}
+}
+
+// TestResourceStripeCouponRead_appliesToExpand checks that applies_to is only expanded for a coupon
+// attached to products, and still read back then.
+func TestResourceStripeCouponRead_appliesToExpand(t *testing.T) {
+	cases := []struct {
+		name      string
+		appliesTo []string
+		expanded  bool
+	}{
+		{"no products", []string{}, false},
+		{"products", []string{"prod_123"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path != "/v1/coupons/test" {
+					fmt.Fprint(w, `{"id": "prod_123", "object": "product", "name": "Pro plan"}`)
+					return
+				}
+				expanded := false
+				for key, values := range r.URL.Query() {
+					expanded = expanded || (strings.HasPrefix(key, "expand") && values[0] == "applies_to")
+				}
+				if expanded != tc.expanded {
+					t.Errorf("expected applies_to expanded %t, got %v", tc.expanded, r.URL.Query())
+				}
+				appliesTo := ""
+				if expanded {
+					appliesTo = `, "applies_to": {"products": ["prod_123"]}`
+				}
+				fmt.Fprintf(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25%s}`, appliesTo)
+			})
+
+			var appliesTo []interface{}
+			for _, productID := range tc.appliesTo {
+				appliesTo = append(appliesTo, productID)
+			}
+			d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+				"percent_off": 25,
+				"applies_to":  appliesTo,
+			})
+			d.SetId("test")
+			if diags := resourceStripeCouponRead(context.Background(), d, &Config{API: api}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if appliesTo := ExtractStringSlice(d, "applies_to"); !reflect.DeepEqual(appliesTo, tc.appliesTo) {
+				t.Errorf("expected applies_to %v, got %v", tc.appliesTo, appliesTo)
+			}
+		})
+	}
+}
+
+// TestResourceStripeCouponDelete_redeemedWarning checks the warning about the lost redemption history,
+// which is how replacing a coupon over e.g. its currency is called out.
+func TestResourceStripeCouponDelete_redeemedWarning(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "deleted": true}`)
+	})
+
+	cases := []struct {
+		name          string
+		timesRedeemed int
+		warning       bool
+	}{
+		{"never redeemed", 0, false},
+		{"redeemed", 3, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+				"currency":   "usd",
+				"amount_off": 1000,
+			})
+			d.SetId("test")
+			if err := d.Set("times_redeemed", tc.timesRedeemed); err != nil {
+				t.Fatal(err)
+			}
+
+			diags := resourceStripeCouponDelete(context.Background(), d, &Config{API: api})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != "" {
+				t.Errorf("expected the coupon to be removed from the state")
+			}
+			if !tc.warning {
+				if len(diags) > 0 {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Severity != diag.Warning {
+				t.Fatalf("expected a single warning, got %v", diags)
+			}
+			if !strings.Contains(diags[0].Summary, "redeemed 3 times") {
+				t.Errorf("unexpected warning %q", diags[0].Summary)
+			}
+		})
+	}
+}
+
+// TestResourceStripeCoupon_errorAttributePath checks that errors Stripe reports for a parameter
+// point at the argument, on create as well as on update.
+func TestResourceStripeCoupon_errorAttributePath(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		param := "amount_off"
+		if r.URL.Path == "/v1/coupons/test" {
+			param = "name"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Request-Id", "req_123")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error": {"type": "invalid_request_error", "message": "Invalid %s", "param": %q}}`, param, param)
+	})
+	config := &Config{API: api}
+
+	d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+		"amount_off": 1000,
+		"currency":   "usd",
+	})
+	diags := resourceStripeCouponCreate(context.Background(), d, config)
+	if !diags.HasError() || !diags[0].AttributePath.Equals(cty.GetAttrPath("amount_off")) {
+		t.Errorf("expected an error at amount_off, got %#v", diags)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+		"name":        "Launch",
+		"percent_off": 25,
+	})
+	d.SetId("test")
+	diags = resourceStripeCouponUpdate(context.Background(), d, config)
+	if !diags.HasError() || !diags[0].AttributePath.Equals(cty.GetAttrPath("name")) {
+		t.Errorf("expected an error at name, got %#v", diags)
+	}
+}
+
+// TestResourceStripeCouponDiff_amountOffCurrency checks that amount_off and currency are planned together,
+// Stripe would otherwise only reject the coupon during the apply.
+func TestResourceStripeCouponDiff_amountOffCurrency(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  map[string]interface{}
+		err  string
+	}{
+		{"amount_off and currency", map[string]interface{}{"amount_off": 1000, "currency": "usd"}, ""},
+		{"currency alone", map[string]interface{}{"percent_off": 25, "currency": "usd"},
+			"currency can only be set together with amount_off"},
+		{"amount_off alone", map[string]interface{}{"amount_off": 1000}, "currency is required when amount_off is set"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resourceStripeCoupon().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.raw), &Config{})
+			if tc.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+// testApplyCoupon plans the coupon configuration against the state and applies the plan.
+func testApplyCoupon(t *testing.T, config *Config, state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	r := resourceStripeCoupon()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	state, diags := r.Apply(context.Background(), state, diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	return state
+}
+
+// TestResourceStripeCouponUpdate_removedMetadata removes one of two metadata keys, the fake Stripe,
+// like Stripe, only deletes a key which is sent empty.
+func TestResourceStripeCouponUpdate_removedMetadata(t *testing.T) {
+	metadata := map[string]string{}
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for key, values := range r.PostForm {
+			if k := strings.TrimSuffix(strings.TrimPrefix(key, "metadata["), "]"); k != key {
+				if values[0] == "" {
+					delete(metadata, k)
+				} else {
+					metadata[k] = values[0]
+				}
+			}
+		}
+		body, err := json.Marshal(metadata)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25, "metadata": %s}`, body)
+	})
+	config := &Config{API: api}
+
+	coupon := map[string]interface{}{
+		"percent_off":     25,
+		"idempotency_key": "test",
+		"metadata":        map[string]interface{}{"team": "growth", "campaign": "launch"},
+	}
+	state := testApplyCoupon(t, config, nil, coupon)
+
+	coupon["metadata"] = map[string]interface{}{"team": "growth"}
+	state = testApplyCoupon(t, config, state, coupon)
+
+	if count := state.Attributes["metadata.%"]; count != "1" || state.Attributes["metadata.team"] != "growth" {
+		t.Errorf("expected only the team metadata, got %v", state.Attributes)
+	}
+	if _, kept := metadata["campaign"]; kept {
+		t.Errorf("expected the campaign metadata deleted on Stripe, got %v", metadata)
+	}
+}
+
+// TestResourceStripeCouponRead_notFound refreshes a coupon deleted outside of Terraform,
+// it's dropped from the state while other errors keep failing the refresh.
+func TestResourceStripeCouponRead_notFound(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		err    bool
+	}{
+		{"not found", http.StatusNotFound,
+			`{"error": {"type": "invalid_request_error", "code": "resource_missing", "message": "No such coupon: 'test'"}}`, false},
+		{"server error", http.StatusInternalServerError,
+			`{"error": {"type": "api_error", "message": "Something went wrong"}}`, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			})
+			d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{"percent_off": 25})
+			d.SetId("test")
+
+			diags := resourceStripeCouponRead(context.Background(), d, &Config{API: api})
+			if diags.HasError() != tc.err {
+				t.Fatalf("expected an error %t, got %v", tc.err, diags)
+			}
+			if removed := d.Id() == ""; removed == tc.err {
+				t.Errorf("expected the coupon removed from the state %t, got the ID %q", !tc.err, d.Id())
+			}
+		})
+	}
+}
+
+// TestResourceStripeCouponDiff_redeemBy checks that a redeem_by in the past fails the plan,
+// unless it's the redemption date of an existing coupon which passed since.
+func TestResourceStripeCouponDiff_redeemBy(t *testing.T) {
+	past := "2020-01-01T00:00:00Z"
+	future := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	cases := []struct {
+		name     string
+		state    string
+		redeemBy string
+		err      bool
+	}{
+		{"future", "", future, false},
+		{"past", "", past, true},
+		{"passed since", past, past, false},
+		{"changed to the past", future, past, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tc.state != "" {
+				state = &terraform.InstanceState{ID: "test", Attributes: map[string]string{
+					"id":          "test",
+					"percent_off": "25",
+					"duration":    "once",
+					"redeem_by":   tc.state,
+				}}
+			}
+			raw := map[string]interface{}{"percent_off": 25, "redeem_by": tc.redeemBy}
+			_, err := resourceStripeCoupon().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), &Config{})
+			if tc.err {
+				if err == nil || !strings.Contains(err.Error(), "is in the past") {
+					t.Errorf("expected a redeem_by in the past error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestResourceStripeCoupon_requestID checks that every coupon operation keeps the Stripe request ID
+// of a failed request in the diagnostic.
+func TestResourceStripeCoupon_requestID(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Request-Id", "req_123")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "Invalid API Key provided"}}`)
+	})
+	config := &Config{API: api}
+
+	operations := map[string]func(*schema.ResourceData) diag.Diagnostics{
+		"create": func(d *schema.ResourceData) diag.Diagnostics {
+			return resourceStripeCouponCreate(context.Background(), d, config)
+		},
+		"read": func(d *schema.ResourceData) diag.Diagnostics {
+			d.SetId("test")
+			return resourceStripeCouponRead(context.Background(), d, config)
+		},
+		"update": func(d *schema.ResourceData) diag.Diagnostics {
+			d.SetId("test")
+			return resourceStripeCouponUpdate(context.Background(), d, config)
+		},
+		"delete": func(d *schema.ResourceData) diag.Diagnostics {
+			d.SetId("test")
+			return resourceStripeCouponDelete(context.Background(), d, config)
+		},
+	}
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{
+				"name":        "Launch",
+				"percent_off": 25,
+			})
+			diags := operation(d)
+			if !diags.HasError() || !strings.Contains(diags[0].Detail, "Stripe request ID: req_123") {
+				t.Errorf("expected the request ID in the error, got %v", diags)
+			}
+		})
+	}
+}
+
+// TestResourceStripeCouponUpdate_changedOnly checks that an update only sends the arguments which changed,
+// and skips the request when none of the updatable arguments did.
+func TestResourceStripeCouponUpdate_changedOnly(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if r.Method == http.MethodPost {
+			requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "name": "Launch", "duration": "once", "percent_off": 25}`)
+	})
+	config := &Config{API: api}
+
+	coupon := map[string]interface{}{
+		"name":            "Launch",
+		"percent_off":     25,
+		"idempotency_key": "test",
+	}
+	state := testApplyCoupon(t, config, nil, coupon)
+
+	coupon["metadata"] = map[string]interface{}{"team": "growth"}
+	state = testApplyCoupon(t, config, state, coupon)
+
+	d := resourceStripeCoupon().Data(state)
+	if diags := resourceStripeCouponUpdate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		"POST /v1/coupons map[duration:[once] name:[Launch] percent_off:[25.0000]]",
+		"POST /v1/coupons/test map[metadata[team]:[growth]]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+// TestResourceStripeCoupon_discount checks that a coupon has a discount and that percent_off is a percentage,
+// both are caught before Stripe is called.
+func TestResourceStripeCoupon_discount(t *testing.T) {
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected string
+	}{
+		{"percent_off", map[string]interface{}{"percent_off": 25}, ""},
+		{"amount_off", map[string]interface{}{"amount_off": 1000, "currency": "usd"}, ""},
+		{"percent_off above 100", map[string]interface{}{"percent_off": 150}, "percent_off"},
+		{"negative percent_off", map[string]interface{}{"percent_off": -5}, "percent_off"},
+		{"no discount", map[string]interface{}{"name": "Launch"}, "one of amount_off or percent_off has to be set"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(tc.raw)
+			diags := resourceStripeCoupon().Validate(config)
+			if !diags.HasError() {
+				_, err := resourceStripeCoupon().Diff(context.Background(), nil, config, &Config{})
+				diags = diag.FromErr(err)
+			}
+			if tc.expected == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() {
+				t.Fatalf("expected an error mentioning %s", tc.expected)
+			}
+			if summary := diags[0].Summary + diags[0].Detail; !strings.Contains(summary, tc.expected) {
+				t.Errorf("expected an error mentioning %s, got %q", tc.expected, summary)
+			}
+		})
+	}
+}
+
+// TestResourceStripeCoupon_customID creates a coupon with a memorable ID and imports it by that ID.
+func TestResourceStripeCoupon_customID(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "LAUNCH2024", "object": "coupon", "duration": "once", "percent_off": 25}`)
+	})
+	config := &Config{API: api}
+
+	state := testApplyCoupon(t, config, nil, map[string]interface{}{
+		"id":              "LAUNCH2024",
+		"percent_off":     25,
+		"idempotency_key": "test",
+	})
+	if state.ID != "LAUNCH2024" {
+		t.Errorf("expected the coupon LAUNCH2024, got %q", state.ID)
+	}
+
+	d := resourceStripeCoupon().Data(nil)
+	d.SetId("LAUNCH2024")
+	imported, err := resourceStripeCouponImport(context.Background(), d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags := resourceStripeCouponRead(context.Background(), imported[0], config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if id := imported[0].Id(); id != "LAUNCH2024" {
+		t.Errorf("expected the coupon LAUNCH2024 imported, got %q", id)
+	}
+
+	expected := []string{
+		"POST /v1/coupons map[duration:[once] id:[LAUNCH2024] percent_off:[25.0000]]",
+		"GET /v1/coupons/LAUNCH2024 map[]",
+		"GET /v1/coupons/LAUNCH2024 map[]",
+		"GET /v1/coupons/LAUNCH2024 map[]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+// TestResourceStripeCoupon_generatedID checks that Stripe generates the ID of a coupon which doesn't set one,
+// and that changing a set ID replaces the coupon.
+func TestResourceStripeCoupon_generatedID(t *testing.T) {
+	cases := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{"supplied", "LAUNCH2024", "LAUNCH2024"},
+		{"generated", "", "Z4OV52SU"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent []string
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if r.Method == http.MethodPost {
+					sent = r.PostForm["id"]
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id": %q, "object": "coupon", "duration": "once", "percent_off": 25}`, tc.expected)
+			})
+			config := &Config{API: api}
+
+			raw := map[string]interface{}{"percent_off": 25, "idempotency_key": "test"}
+			if tc.id != "" {
+				raw["id"] = tc.id
+			}
+			state := testApplyCoupon(t, config, nil, raw)
+			if state.ID != tc.expected {
+				t.Errorf("expected the coupon %s, got %q", tc.expected, state.ID)
+			}
+			if sentID := strings.Join(sent, ","); sentID != tc.id {
+				t.Errorf("expected the id %q sent, got %q", tc.id, sentID)
+			}
+
+			raw["id"] = "SUMMER2024"
+			diff, err := resourceStripeCoupon().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !diff.RequiresNew() {
+				t.Errorf("expected a new ID to replace the coupon")
+			}
+		})
+	}
+}
+
+func TestResourceStripeCouponDiff_durationInMonths(t *testing.T) {
+	cases := []struct {
+		duration string
+		err      bool
+	}{
+		{"repeating", false},
+		{"once", true},
+		{"forever", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.duration, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"percent_off":        25,
+				"duration":           tc.duration,
+				"duration_in_months": 3,
+			}
+			_, err := resourceStripeCoupon().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), &Config{})
+			if tc.err {
+				if err == nil || !strings.Contains(err.Error(), "duration_in_months can only be set when duration is repeating") {
+					t.Errorf("expected a duration_in_months error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestResourceStripeCoupon_currencyOptions creates a coupon taking 10 USD or 9 EUR off, the fake Stripe returns
+// the coupon's own currency among the currency_options like Stripe does.
+func TestResourceStripeCoupon_currencyOptions(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if r.Method == http.MethodPost {
+			requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "amount_off": 1000, "currency": "usd",
+			"currency_options": {"usd": {"amount_off": 1000}, "eur": {"amount_off": 900}}}`)
+	})
+	config := &Config{API: api}
+
+	state := testApplyCoupon(t, config, nil, map[string]interface{}{
+		"amount_off":      1000,
+		"currency":        "usd",
+		"idempotency_key": "test",
+		"currency_options": []interface{}{
+			map[string]interface{}{"currency": "eur", "amount_off": 900},
+		},
+	})
+
+	expected := []string{
+		"POST /v1/coupons map[amount_off:[1000] currency:[usd] currency_options[eur][amount_off]:[900] duration:[once]]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+	d := resourceStripeCoupon().Data(state)
+	options := d.Get("currency_options").(*schema.Set).List()
+	if len(options) != 1 || !reflect.DeepEqual(options[0], map[string]interface{}{"currency": "eur", "amount_off": 900}) {
+		t.Errorf("expected only the eur option read back, got %v", options)
+	}
+}
+
+// TestResourceStripeCoupon_redeemByEpoch creates a coupon with redeem_by in Unix epoch seconds,
+// the RFC3339 date read back is the same time and plans no change.
+func TestResourceStripeCoupon_redeemByEpoch(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if r.Method == http.MethodPost {
+			requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25, "redeem_by": 1893456000}`)
+	})
+	config := &Config{API: api}
+
+	coupon := map[string]interface{}{
+		"percent_off":     25,
+		"redeem_by":       "1893456000",
+		"idempotency_key": "test",
+	}
+	state := testApplyCoupon(t, config, nil, coupon)
+	if redeemBy := state.Attributes["redeem_by"]; redeemBy != "2030-01-01T00:00:00Z" {
+		t.Errorf("expected redeem_by stored as 2030-01-01T00:00:00Z, got %q", redeemBy)
+	}
+
+	diff, err := resourceStripeCoupon().Diff(context.Background(), state, terraform.NewResourceConfigRaw(coupon), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes, got %v", diff)
+	}
+
+	expected := []string{
+		"POST /v1/coupons map[duration:[once] percent_off:[25.0000] redeem_by:[1893456000]]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+// TestFindCouponByName covers the lookup shared by the coupon import and the coupon data source.
+func TestFindCouponByName(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"object": "list", "url": "/v1/coupons", "has_more": false, "data": [
+			{"id": "LAUNCH", "object": "coupon", "name": "Launch"},
+			{"id": "BF1", "object": "coupon", "name": "Black Friday"},
+			{"id": "BF2", "object": "coupon", "name": "Black Friday"}
+		]}`)
+	})
+
+	cases := []struct {
+		name     string
+		expected string
+		err      string
+	}{
+		{"Launch", "LAUNCH", ""},
+		{"Summer", "", `no coupon found with name "Summer"`},
+		{"Black Friday", "", `multiple coupons found with name "Black Friday"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			coupon, err := findCouponByName(context.Background(), api, tc.name)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if coupon.ID != tc.expected {
+				t.Errorf("expected coupon %s, got %s", tc.expected, coupon.ID)
+			}
+		})
+	}
+}
+
+func testAccCheckStripeCouponDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*Config).API
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_coupon" {
+			continue
+		}
+
+		_, err := c.Coupons.Get(rs.Primary.ID, nil)
+		if err == nil {
+			return fmt.Errorf("coupon %s still exists", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccStripeCouponConfig(name string) string {
+	return fmt.Sprintf(`
+resource "stripe_coupon" "test" {
+  name        = %q
+  percent_off = 25
+  duration    = "once"
+}
+`, name)
+}
@@ -0,0 +1,265 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeCreditNote() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCreditNoteRead,
+		CreateContext: resourceStripeCreditNoteCreate,
+		UpdateContext: resourceStripeCreditNoteUpdate,
+		DeleteContext: resourceStripeCreditNoteDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"invoice": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the invoice.",
+			},
+			"amount": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The integer amount in cents representing the total amount of the credit note. " +
+					"Computed from the lines when not set.",
+			},
+			"credit_amount": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Description: "The integer amount in cents representing the amount " +
+					"to credit the customer’s balance, which will be automatically applied to their next invoice.",
+			},
+			"reason": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.CreditNoteReasonDuplicate),
+					string(stripe.CreditNoteReasonFraudulent),
+					string(stripe.CreditNoteReasonOrderChange),
+					string(stripe.CreditNoteReasonProductUnsatisfactory),
+				}, false),
+				Description: "Reason for issuing this credit note, one of duplicate, fraudulent, " +
+					"order_change, or product_unsatisfactory.",
+			},
+			"lines": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Line items that make up the credit note.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.CreditNoteLineItemTypeCustomLineItem),
+								string(stripe.CreditNoteLineItemTypeInvoiceLineItem),
+							}, false),
+							Description: "Type of the credit note line item, one of invoice_line_item or custom_line_item.",
+						},
+						"invoice_line_item": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The invoice line item to credit. Only valid when the type is invoice_line_item.",
+						},
+						"amount": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The line item amount to credit. Only valid when the type is invoice_line_item.",
+						},
+						"quantity": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The line item quantity to credit.",
+						},
+						"unit_amount": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The integer unit amount in cents of the credit note line item.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The description of the credit note line item. Only valid when the type is custom_line_item.",
+						},
+					},
+				},
+			},
+			"memo": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The credit note’s memo appears on the credit note PDF.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A unique number that identifies this particular credit note and appears on the PDF.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of this credit note, one of issued or void.",
+			},
+			"pdf": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The link to download the PDF of the credit note.",
+			},
+		},
+	}
+}
+
+func resourceStripeCreditNoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	creditNote, err := c.CreditNotes.Get(d.Id(), &stripe.CreditNoteParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("invoice", creditNote.Invoice.ID),
+		d.Set("amount", creditNote.Amount),
+		d.Set("reason", creditNote.Reason),
+		d.Set("memo", creditNote.Memo),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(creditNote.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("number", creditNote.Number),
+		d.Set("status", creditNote.Status),
+		d.Set("pdf", creditNote.PDF),
+	)
+}
+
+func resourceStripeCreditNoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.CreditNoteParams{
+		Invoice: stripe.String(ExtractString(d, "invoice")),
+	}
+
+	if amount, set := d.GetOk("amount"); set {
+		params.Amount = stripe.Int64(ToInt64(amount))
+	}
+	if creditAmount, set := d.GetOk("credit_amount"); set {
+		params.CreditAmount = stripe.Int64(ToInt64(creditAmount))
+	}
+	if reason, set := d.GetOk("reason"); set {
+		params.Reason = stripe.String(ToString(reason))
+	}
+	if lines, set := d.GetOk("lines"); set {
+		for _, l := range ToSlice(lines) {
+			line := &stripe.CreditNoteLineParams{}
+			for k, v := range ToMap(l) {
+				switch k {
+				case "type":
+					line.Type = stripe.String(ToString(v))
+				case "invoice_line_item":
+					if invoiceLineItem := ToString(v); invoiceLineItem != "" {
+						line.InvoiceLineItem = stripe.String(invoiceLineItem)
+					}
+				case "amount":
+					if amount := ToInt64(v); amount != 0 {
+						line.Amount = stripe.Int64(amount)
+					}
+				case "quantity":
+					if quantity := ToInt64(v); quantity != 0 {
+						line.Quantity = stripe.Int64(quantity)
+					}
+				case "unit_amount":
+					if unitAmount := ToInt64(v); unitAmount != 0 {
+						line.UnitAmount = stripe.Int64(unitAmount)
+					}
+				case "description":
+					if description := ToString(v); description != "" {
+						line.Description = stripe.String(description)
+					}
+				}
+			}
+			params.Lines = append(params.Lines, line)
+		}
+	}
+	if memo, set := d.GetOk("memo"); set {
+		params.Memo = stripe.String(ToString(memo))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	creditNote, err := c.CreditNotes.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(creditNote.ID)
+	return resourceStripeCreditNoteRead(ctx, d, m)
+}
+
+func resourceStripeCreditNoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.CreditNoteParams{}
+
+	if d.HasChange("memo") {
+		params.Memo = stripe.String(ExtractString(d, "memo"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.CreditNotes.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeCreditNoteRead(ctx, d, m)
+}
+
+func resourceStripeCreditNoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	if stripe.CreditNoteStatus(ExtractString(d, "status")) == stripe.CreditNoteStatusVoid {
+		log.Printf("[WARN] Credit Note %s is already void, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_, err := c.CreditNotes.VoidCreditNote(d.Id(), &stripe.CreditNoteVoidParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripeCustomer() *schema.Resource {
@@ -21,6 +20,7 @@ func resourceStripeCustomer() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"idempotency_key": idempotencyKeySchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -121,11 +121,14 @@ func resourceStripeCustomer() *schema.Resource {
 	}
 }
 
-func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeCustomerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 
-	customer, err := c.Customers.Get(d.Id(), nil)
+	customer, err := c.Customers.Get(d.Id(), &stripe.CustomerParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -134,29 +137,7 @@ func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m int
 		d.Set("email", customer.Email),
 		d.Set("description", customer.Description),
 		d.Set("phone", customer.Phone),
-		func() error {
-			addressMap := make(map[string]interface{})
-			if customer.Address.Line1 != "" {
-				addressMap["line1"] = customer.Address.Line1
-			}
-			if customer.Address.Line2 != "" {
-				addressMap["line2"] = customer.Address.Line2
-			}
-			if customer.Address.City != "" {
-				addressMap["city"] = customer.Address.City
-			}
-			if customer.Address.State != "" {
-				addressMap["state"] = customer.Address.State
-			}
-			if customer.Address.PostalCode != "" {
-				addressMap["postal_code"] = customer.Address.PostalCode
-			}
-			if customer.Address.Country != "" {
-				addressMap["country"] = customer.Address.Country
-			}
-
-			return d.Set("address", addressMap)
-		}(),
+		d.Set("address", flattenAddressMap(&customer.Address)),
 		func() error {
 			if customer.Shipping != nil {
 				shippingMap := make(map[string]interface{})
@@ -166,23 +147,8 @@ func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m int
 				if customer.Shipping.Phone != "" {
 					shippingMap["phone"] = customer.Shipping.Phone
 				}
-				if customer.Shipping.Address.Line1 != "" {
-					shippingMap["line1"] = customer.Shipping.Address.Line1
-				}
-				if customer.Shipping.Address.Line2 != "" {
-					shippingMap["line2"] = customer.Shipping.Address.Line2
-				}
-				if customer.Shipping.Address.City != "" {
-					shippingMap["city"] = customer.Shipping.Address.City
-				}
-				if customer.Shipping.Address.State != "" {
-					shippingMap["state"] = customer.Shipping.Address.State
-				}
-				if customer.Shipping.Address.PostalCode != "" {
-					shippingMap["postal_code"] = customer.Shipping.Address.PostalCode
-				}
-				if customer.Shipping.Address.Country != "" {
-					shippingMap["country"] = customer.Shipping.Address.Country
+				for k, v := range flattenAddressMap(&customer.Shipping.Address) {
+					shippingMap[k] = v
 				}
 				return d.Set("shipping", shippingMap)
 			}
@@ -202,7 +168,9 @@ func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m int
 				if customer.InvoiceSettings.Footer != "" {
 					invoiceSettingsMap["footer"] = customer.InvoiceSettings.Footer
 				}
-				if customer.InvoiceSettings.DefaultPaymentMethod != nil {
+				// the default payment method may be managed by stripe_customer_default_payment_method instead
+				_, configured := ExtractMap(d, "invoice_settings")["default_payment_method"]
+				if configured && customer.InvoiceSettings.DefaultPaymentMethod != nil {
 					invoiceSettingsMap["default_payment_method"] = customer.InvoiceSettings.DefaultPaymentMethod.ID
 				}
 				for _, field := range customer.InvoiceSettings.CustomFields {
@@ -215,12 +183,12 @@ func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m int
 		}(),
 		d.Set("next_invoice_sequence", customer.NextInvoiceSequence),
 		d.Set("preferred_locales", customer.PreferredLocales),
-		d.Set("metadata", customer.Metadata),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(customer.Metadata, ExtractMap(d, "metadata"))),
 	)
 }
 
 func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.CustomerParams{}
 
 	if name, set := d.GetOk("name"); set {
@@ -236,51 +204,18 @@ func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m
 		params.Phone = stripe.String(ToString(phone))
 	}
 	if address, set := d.GetOk("address"); set {
-		params.Address = &stripe.AddressParams{}
-		addressMap := ToMap(address)
-		for k, v := range addressMap {
-			value := stripe.String(ToString(v))
-			switch k {
-			case "line1":
-				params.Address.Line1 = value
-			case "line2":
-				params.Address.Line2 = value
-			case "city":
-				params.Address.City = value
-			case "state":
-				params.Address.State = value
-			case "postal_code":
-				params.Address.PostalCode = value
-			case "country":
-				params.Address.Country = value
-			}
-		}
+		params.Address = expandAddress(address)
 	}
 	if shipping, set := d.GetOk("shipping"); set {
+		shippingMap := ToMap(shipping)
 		params.Shipping = &stripe.CustomerShippingDetailsParams{
-			Address: &stripe.AddressParams{},
+			Address: expandAddress(shippingMap),
 		}
-		shippingMap := ToMap(shipping)
-		for k, v := range shippingMap {
-			value := stripe.String(ToString(v))
-			switch k {
-			case "name":
-				params.Shipping.Name = value
-			case "phone":
-				params.Shipping.Phone = value
-			case "line1":
-				params.Shipping.Address.Line1 = value
-			case "line2":
-				params.Shipping.Address.Line2 = value
-			case "city":
-				params.Shipping.Address.City = value
-			case "state":
-				params.Shipping.Address.State = value
-			case "postal_code":
-				params.Shipping.Address.PostalCode = value
-			case "country":
-				params.Shipping.Address.Country = value
-			}
+		if name, set := shippingMap["name"]; set {
+			params.Shipping.Name = stripe.String(ToString(name))
+		}
+		if phone, set := shippingMap["phone"]; set {
+			params.Shipping.Phone = stripe.String(ToString(phone))
 		}
 	}
 	if balance, set := d.GetOk("balance"); set {
@@ -314,12 +249,10 @@ func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m
 	if preferredLocales, set := d.GetOk("preferred_locales"); set {
 		params.PreferredLocales = stripe.StringSlice(ToStringSlice(preferredLocales))
 	}
-	if meta, set := d.GetOk("metadata"); set {
-		for k, v := range ToMap(meta) {
-			params.AddMetadata(k, ToString(v))
-		}
-	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
 
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
 	customer, err := c.Customers.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -330,7 +263,7 @@ func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.CustomerParams{}
 
 	if d.HasChange("name") {
@@ -346,51 +279,18 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 		params.Phone = stripe.String(ExtractString(d, "phone"))
 	}
 	if d.HasChange("address") {
-		params.Address = &stripe.AddressParams{}
-		addressMap := ExtractMap(d, "address")
-		for k, v := range addressMap {
-			value := stripe.String(ToString(v))
-			switch k {
-			case "line1":
-				params.Address.Line1 = value
-			case "line2":
-				params.Address.Line2 = value
-			case "city":
-				params.Address.City = value
-			case "state":
-				params.Address.State = value
-			case "postal_code":
-				params.Address.PostalCode = value
-			case "country":
-				params.Address.Country = value
-			}
-		}
+		params.Address = expandAddress(d.Get("address"))
 	}
 	if d.HasChange("shipping") {
+		shippingMap := ExtractMap(d, "shipping")
 		params.Shipping = &stripe.CustomerShippingDetailsParams{
-			Address: &stripe.AddressParams{},
+			Address: expandAddress(shippingMap),
 		}
-		shippingMap := ExtractMap(d, "shipping")
-		for k, v := range shippingMap {
-			value := stripe.String(ToString(v))
-			switch k {
-			case "name":
-				params.Shipping.Name = value
-			case "phone":
-				params.Shipping.Phone = value
-			case "line1":
-				params.Shipping.Address.Line1 = value
-			case "line2":
-				params.Shipping.Address.Line2 = value
-			case "city":
-				params.Shipping.Address.City = value
-			case "state":
-				params.Shipping.Address.State = value
-			case "postal_code":
-				params.Shipping.Address.PostalCode = value
-			case "country":
-				params.Shipping.Address.Country = value
-			}
+		if name, set := shippingMap["name"]; set {
+			params.Shipping.Name = stripe.String(ToString(name))
+		}
+		if phone, set := shippingMap["phone"]; set {
+			params.Shipping.Phone = stripe.String(ToString(phone))
 		}
 	}
 	if d.HasChange("balance") {
@@ -425,13 +325,10 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 		params.PreferredLocales = stripe.StringSlice(ExtractStringSlice(d, "preferred_locales"))
 	}
 	if d.HasChange("metadata") {
-		params.Metadata = nil
-		metadata := ExtractMap(d, "metadata")
-		for k, v := range metadata {
-			params.AddMetadata(k, ToString(v))
-		}
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
 	}
 
+	params.Context = ctx
 	_, err := c.Customers.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -441,10 +338,10 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 
 }
 
-func resourceStripeCustomerDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeCustomerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 
-	_, err := c.Customers.Del(d.Id(), nil)
+	_, err := c.Customers.Del(d.Id(), &stripe.CustomerParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,146 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeCustomerBalanceTransaction() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCustomerBalanceTransactionRead,
+		CreateContext: resourceStripeCustomerBalanceTransactionCreate,
+		UpdateContext: resourceStripeCustomerBalanceTransactionUpdate,
+		DeleteContext: resourceStripeCustomerBalanceTransactionDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer the transaction belongs to.",
+			},
+			"amount": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+				Description: "The integer amount in cents to apply to the customer’s credit balance. " +
+					"A negative amount is a credit, a positive amount a debit.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary string attached to the object. Often useful for displaying to users.",
+			},
+			"ending_balance": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "The customer’s balance after the transaction was applied. " +
+					"A negative value decreases the amount due on the customer’s next invoice.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Transaction type, adjustment for transactions created through this resource.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+		},
+	}
+}
+
+func resourceStripeCustomerBalanceTransactionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+	params.Context = ctx
+	transaction, err := c.CustomerBalanceTransactions.Get(d.Id(), params)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("amount", transaction.Amount),
+		d.Set("currency", transaction.Currency),
+		d.Set("description", transaction.Description),
+		d.Set("ending_balance", transaction.EndingBalance),
+		d.Set("type", transaction.Type),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(transaction.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
+
+func resourceStripeCustomerBalanceTransactionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+		Amount:   stripe.Int64(ExtractInt64(d, "amount")),
+		Currency: stripe.String(ExtractString(d, "currency")),
+	}
+
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	transaction, err := c.CustomerBalanceTransactions.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(transaction.ID)
+	return resourceStripeCustomerBalanceTransactionRead(ctx, d, m)
+}
+
+func resourceStripeCustomerBalanceTransactionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.CustomerBalanceTransactions.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeCustomerBalanceTransactionRead(ctx, d, m)
+}
+
+func resourceStripeCustomerBalanceTransactionDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("[WARN] Stripe SDK doesn't support Customer Balance Transaction deletion through API!")
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,172 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// cash balances aren't part of stripe-go v72, the customer's cash balance endpoint is called directly
+type customerCashBalanceSettingsParams struct {
+	ReconciliationMode *string `form:"reconciliation_mode"`
+}
+
+type customerCashBalanceParams struct {
+	stripe.Params `form:"*"`
+	Settings      *customerCashBalanceSettingsParams `form:"settings"`
+}
+
+type customerCashBalance struct {
+	stripe.APIResource
+	Available map[string]int64 `json:"available"`
+	Customer  string           `json:"customer"`
+	Settings  struct {
+		ReconciliationMode   string `json:"reconciliation_mode"`
+		UsingMerchantDefault bool   `json:"using_merchant_default"`
+	} `json:"settings"`
+}
+
+func resourceStripeCustomerCashBalance() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCustomerCashBalanceRead,
+		CreateContext: resourceStripeCustomerCashBalanceCreate,
+		UpdateContext: resourceStripeCustomerCashBalanceUpdate,
+		DeleteContext: resourceStripeCustomerCashBalanceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the customer, a customer has a single cash balance.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer whose cash balance is managed.",
+			},
+			"settings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Settings controlling the behavior of the customer’s cash balance.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"reconciliation_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"automatic",
+								"manual",
+								"merchant_default",
+							}, false),
+							Description: "The configuration for how funds that land in the customer cash balance are reconciled, " +
+								"one of automatic, manual or merchant_default.",
+						},
+						"using_merchant_default": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the reconciliation mode follows the default of the account.",
+						},
+					},
+				},
+			},
+			"available": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Description: "The funds available to the customer, keyed by currency " +
+					"with amounts in the smallest currency unit.",
+			},
+		},
+	}
+}
+
+func resourceStripeCustomerCashBalanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cashBalance := &customerCashBalance{}
+	path := stripe.FormatURLPath("/v1/customers/%s/cash_balance", d.Id())
+	err := rawCall(m, http.MethodGet, path, &stripe.Params{Context: ctx}, cashBalance)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("customer", cashBalance.Customer),
+		d.Set("settings", []map[string]interface{}{
+			{
+				"reconciliation_mode":    cashBalance.Settings.ReconciliationMode,
+				"using_merchant_default": cashBalance.Settings.UsingMerchantDefault,
+			},
+		}),
+		d.Set("available", cashBalance.Available),
+	)
+}
+
+// resourceStripeCustomerCashBalanceCreate takes over the cash balance every customer already has,
+// only its settings are sent.
+func resourceStripeCustomerCashBalanceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	customer := ExtractString(d, "customer")
+	if settings, set := d.GetOk("settings"); set {
+		err := updateCustomerCashBalanceSettings(ctx, m.(*Config), customer, expandCustomerCashBalanceSettings(settings))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(customer)
+	return resourceStripeCustomerCashBalanceRead(ctx, d, m)
+}
+
+func resourceStripeCustomerCashBalanceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("settings") {
+		err := updateCustomerCashBalanceSettings(ctx, m.(*Config), d.Id(), expandCustomerCashBalanceSettings(d.Get("settings")))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeCustomerCashBalanceRead(ctx, d, m)
+}
+
+// resourceStripeCustomerCashBalanceDelete hands the reconciliation back to the account default,
+// the cash balance itself stays with the customer.
+func resourceStripeCustomerCashBalanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	err := updateCustomerCashBalanceSettings(ctx, m.(*Config), d.Id(), &customerCashBalanceSettingsParams{
+		ReconciliationMode: stripe.String("merchant_default"),
+	})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func updateCustomerCashBalanceSettings(ctx context.Context, config *Config, customer string, settings *customerCashBalanceSettingsParams) error {
+	params := &customerCashBalanceParams{Settings: settings}
+	params.Context = ctx
+	path := stripe.FormatURLPath("/v1/customers/%s/cash_balance", customer)
+	return rawCall(config, http.MethodPost, path, params, &customerCashBalance{})
+}
+
+func expandCustomerCashBalanceSettings(value interface{}) *customerCashBalanceSettingsParams {
+	settings := &customerCashBalanceSettingsParams{}
+	if reconciliationMode := ToString(ToMap(value)["reconciliation_mode"]); reconciliationMode != "" {
+		settings.ReconciliationMode = stripe.String(reconciliationMode)
+	}
+	return settings
+}
@@ -0,0 +1,108 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeCustomerDefaultPaymentMethod() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCustomerDefaultPaymentMethodRead,
+		CreateContext: resourceStripeCustomerDefaultPaymentMethodCreate,
+		UpdateContext: resourceStripeCustomerDefaultPaymentMethodUpdate,
+		DeleteContext: resourceStripeCustomerDefaultPaymentMethodDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the customer, a customer has a single default payment method.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer whose default payment method is managed.",
+			},
+			"payment_method": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "ID of a payment method attached to the customer, " +
+					"used as the default for the customer’s subscriptions and invoices.",
+			},
+		},
+	}
+}
+
+func resourceStripeCustomerDefaultPaymentMethodRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	customer, err := c.Customers.Get(d.Id(), &stripe.CustomerParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("customer", customer.ID),
+		func() error {
+			if customer.InvoiceSettings != nil && customer.InvoiceSettings.DefaultPaymentMethod != nil {
+				return d.Set("payment_method", customer.InvoiceSettings.DefaultPaymentMethod.ID)
+			}
+			return d.Set("payment_method", "")
+		}(),
+	)
+}
+
+func resourceStripeCustomerDefaultPaymentMethodCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	customer := ExtractString(d, "customer")
+	if err := setCustomerDefaultPaymentMethod(ctx, m.(*Config), customer, ExtractString(d, "payment_method")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(customer)
+	return resourceStripeCustomerDefaultPaymentMethodRead(ctx, d, m)
+}
+
+func resourceStripeCustomerDefaultPaymentMethodUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("payment_method") {
+		if err := setCustomerDefaultPaymentMethod(ctx, m.(*Config), d.Id(), ExtractString(d, "payment_method")); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeCustomerDefaultPaymentMethodRead(ctx, d, m)
+}
+
+// resourceStripeCustomerDefaultPaymentMethodDelete clears the default payment method,
+// the payment method itself stays attached to the customer.
+func resourceStripeCustomerDefaultPaymentMethodDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := setCustomerDefaultPaymentMethod(ctx, m.(*Config), d.Id(), ""); err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// setCustomerDefaultPaymentMethod updates invoice_settings.default_payment_method alone,
+// an empty paymentMethod clears it.
+func setCustomerDefaultPaymentMethod(ctx context.Context, config *Config, customer, paymentMethod string) error {
+	params := &stripe.CustomerParams{
+		InvoiceSettings: &stripe.CustomerInvoiceSettingsParams{
+			DefaultPaymentMethod: stripe.String(paymentMethod),
+		},
+	}
+	params.Context = ctx
+	_, err := config.API.Customers.Update(customer, params)
+	return err
+}
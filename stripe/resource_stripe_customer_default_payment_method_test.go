@@ -0,0 +1,101 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeCustomerDefaultPaymentMethod_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCustomerDefaultPaymentMethodConfig("first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"stripe_customer_default_payment_method.test", "payment_method",
+						"stripe_payment_method.first", "id"),
+					testAccCheckStripeCustomerDefaultPaymentMethod("stripe_customer.test", "stripe_payment_method.first"),
+				),
+			},
+			{
+				Config: testAccStripeCustomerDefaultPaymentMethodConfig("second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"stripe_customer_default_payment_method.test", "payment_method",
+						"stripe_payment_method.second", "id"),
+					testAccCheckStripeCustomerDefaultPaymentMethod("stripe_customer.test", "stripe_payment_method.second"),
+				),
+			},
+			{
+				ResourceName:      "stripe_customer_default_payment_method.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckStripeCustomerDefaultPaymentMethod compares the default payment method Stripe has for the customer,
+// instead of the one read back into the state.
+func testAccCheckStripeCustomerDefaultPaymentMethod(customerName, paymentMethodName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		customerState, ok := s.RootModule().Resources[customerName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", customerName)
+		}
+		paymentMethodState, ok := s.RootModule().Resources[paymentMethodName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", paymentMethodName)
+		}
+
+		c := testAccProvider.Meta().(*Config).API
+		customer, err := c.Customers.Get(customerState.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+		if customer.InvoiceSettings == nil || customer.InvoiceSettings.DefaultPaymentMethod == nil {
+			return fmt.Errorf("customer %s has no default payment method", customer.ID)
+		}
+		if id := customer.InvoiceSettings.DefaultPaymentMethod.ID; id != paymentMethodState.Primary.ID {
+			return fmt.Errorf("customer %s has the default payment method %s, expected %s",
+				customer.ID, id, paymentMethodState.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccStripeCustomerDefaultPaymentMethodConfig(defaultPaymentMethod string) string {
+	return fmt.Sprintf(`
+resource "stripe_customer" "test" {
+  name = "Default payment method test"
+}
+
+resource "stripe_payment_method" "first" {
+  type     = "card"
+  customer = stripe_customer.test.id
+
+  card {
+    token = "tok_visa"
+  }
+}
+
+resource "stripe_payment_method" "second" {
+  type     = "card"
+  customer = stripe_customer.test.id
+
+  card {
+    token = "tok_mastercard"
+  }
+}
+
+resource "stripe_customer_default_payment_method" "test" {
+  customer       = stripe_customer.test.id
+  payment_method = stripe_payment_method.%s.id
+}
+`, defaultPaymentMethod)
+}
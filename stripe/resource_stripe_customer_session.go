@@ -0,0 +1,146 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// the pinned Stripe SDK predates customer sessions, these mirror the parts of the API the resource uses
+type customerSessionComponentParams struct {
+	Enabled *bool `form:"enabled"`
+}
+
+type customerSessionComponentsParams struct {
+	BuyButton      *customerSessionComponentParams `form:"buy_button"`
+	PaymentElement *customerSessionComponentParams `form:"payment_element"`
+	PricingTable   *customerSessionComponentParams `form:"pricing_table"`
+}
+
+type customerSessionParams struct {
+	stripe.Params `form:"*"`
+	Customer      *string                          `form:"customer"`
+	Components    *customerSessionComponentsParams `form:"components"`
+}
+
+type customerSession struct {
+	stripe.APIResource
+	ClientSecret string `json:"client_secret"`
+	Created      int64  `json:"created"`
+	Customer     string `json:"customer"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+func resourceStripeCustomerSession() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCustomerSessionRead,
+		CreateContext: resourceStripeCustomerSessionCreate,
+		DeleteContext: resourceStripeCustomerSessionDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the session, made of the customer ID and the creation time.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of an existing customer for which to create the customer session.",
+			},
+			"components": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Configuration for each component, at least one component must be enabled.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"buy_button": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Whether the buy button is enabled.",
+						},
+						"payment_element": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Whether the Payment Element is enabled.",
+						},
+						"pricing_table": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Whether the pricing table is enabled.",
+						},
+					},
+				},
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The client secret of this customer session, used on the client to set up the components.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time at which this customer session will expire, in RFC3339 format.",
+			},
+		},
+	}
+}
+
+// resourceStripeCustomerSessionRead keeps the state as it is,
+// Stripe has no endpoint to retrieve a customer session after it is created.
+func resourceStripeCustomerSessionRead(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceStripeCustomerSessionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &customerSessionParams{
+		Customer:   stripe.String(ExtractString(d, "customer")),
+		Components: &customerSessionComponentsParams{},
+	}
+
+	for k, v := range ToMap(d.Get("components")) {
+		if !ToBool(v) {
+			continue
+		}
+		enabled := &customerSessionComponentParams{Enabled: stripe.Bool(true)}
+		switch k {
+		case "buy_button":
+			params.Components.BuyButton = enabled
+		case "payment_element":
+			params.Components.PaymentElement = enabled
+		case "pricing_table":
+			params.Components.PricingTable = enabled
+		}
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	session := &customerSession{}
+	err := rawCall(m, http.MethodPost, "/v1/customer_sessions", params, session)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", session.Customer, session.Created))
+	return CallSet(
+		d.Set("client_secret", session.ClientSecret),
+		d.Set("expires_at", ToRFC3339(session.ExpiresAt)),
+	)
+}
+
+func resourceStripeCustomerSessionDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// customer sessions expire on their own and can't be revoked through the API
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,302 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// disputeEvidenceFields lists the evidence arguments, the file ones take the ID of an uploaded stripe_file
+var disputeEvidenceFields = []struct {
+	name        string
+	file        bool
+	description string
+}{
+	{"access_activity_log", false, "Any server or activity logs showing proof that the customer accessed or downloaded the purchased digital product."},
+	{"billing_address", false, "The billing address provided by the customer."},
+	{"cancellation_policy", true, "Your subscription cancellation policy, as shown to the customer."},
+	{"cancellation_policy_disclosure", false, "An explanation of how and when the customer was shown your refund policy prior to purchase."},
+	{"cancellation_rebuttal", false, "A justification for why the customer’s subscription was not canceled."},
+	{"customer_communication", true, "Any communication with the customer that you feel is relevant to your case."},
+	{"customer_email_address", false, "The email address of the customer."},
+	{"customer_name", false, "The name of the customer."},
+	{"customer_purchase_ip", false, "The IP address that the customer used when making the purchase."},
+	{"customer_signature", true, "A relevant document or contract showing the customer’s signature."},
+	{"duplicate_charge_documentation", true, "Documentation for the prior charge that can uniquely identify the charge."},
+	{"duplicate_charge_explanation", false, "An explanation of the difference between the disputed charge and the prior charge that appears to be a duplicate."},
+	{"duplicate_charge_id", false, "The Stripe ID for the prior charge which appears to be a duplicate of the disputed charge."},
+	{"product_description", false, "A description of the product or service that was sold."},
+	{"receipt", true, "Any receipt or message sent to the customer notifying them of the charge."},
+	{"refund_policy", true, "Your refund policy, as shown to the customer."},
+	{"refund_policy_disclosure", false, "Documentation demonstrating that the customer was shown your refund policy prior to purchase."},
+	{"refund_refusal_explanation", false, "A justification for why the customer is not entitled to a refund."},
+	{"service_date", false, "The date on which the customer received or began receiving the purchased service."},
+	{"service_documentation", true, "Documentation showing proof that a service was provided to the customer."},
+	{"shipping_address", false, "The address to which a physical product was shipped."},
+	{"shipping_carrier", false, "The delivery service that shipped a physical product."},
+	{"shipping_date", false, "The date on which a physical product began its route to the shipping address."},
+	{"shipping_documentation", true, "Documentation showing proof that a product was shipped to the customer at the same address as the customer’s billing address."},
+	{"shipping_tracking_number", false, "The tracking number for a physical product, obtained from the delivery service."},
+	{"uncategorized_file", true, "Any additional evidence or statements."},
+	{"uncategorized_text", false, "Any additional evidence or statements."},
+}
+
+func resourceStripeDispute() *schema.Resource {
+	evidenceSchema := make(map[string]*schema.Schema, len(disputeEvidenceFields))
+	for _, field := range disputeEvidenceFields {
+		description := field.description
+		if field.file {
+			description += " The ID of a file uploaded with the dispute_evidence purpose."
+		}
+		evidenceSchema[field.name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourceStripeDisputeRead,
+		CreateContext: resourceStripeDisputeCreate,
+		UpdateContext: resourceStripeDisputeUpdate,
+		DeleteContext: resourceStripeDisputeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"dispute": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The ID of the dispute to respond to. " +
+					"Disputes are opened by the card networks, they can't be created through the API.",
+			},
+			"evidence": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Evidence to upload, to respond to a dispute.",
+				Elem:        &schema.Resource{Schema: evidenceSchema},
+			},
+			"submit": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether to immediately submit evidence to the bank. " +
+					"Evidence can't be changed anymore once it's submitted.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Current status of the dispute, like needs_response, under_review, " +
+					"won or lost.",
+			},
+			"amount": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Disputed amount, usually the amount of the charge.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code, in lowercase.",
+			},
+			"reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Reason given by cardholder for dispute, like fraudulent, duplicate " +
+					"or product_not_received.",
+			},
+			"charge": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the charge that was disputed.",
+			},
+		},
+	}
+}
+
+func resourceStripeDisputeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	dispute, err := c.Disputes.Get(d.Id(), &stripe.DisputeParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("dispute", dispute.ID),
+		func() error {
+			if _, set := d.GetOk("evidence"); set && dispute.Evidence != nil {
+				return d.Set("evidence", flattenDisputeEvidence(dispute.Evidence))
+			}
+			return nil
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(dispute.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", dispute.Status),
+		d.Set("amount", dispute.Amount),
+		d.Set("currency", dispute.Currency),
+		d.Set("reason", dispute.Reason),
+		func() error {
+			if dispute.Charge != nil {
+				return d.Set("charge", dispute.Charge.ID)
+			}
+			return nil
+		}(),
+	)
+}
+
+// resourceStripeDisputeCreate takes over a dispute opened on one of the account's payments.
+func resourceStripeDisputeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.DisputeParams{}
+
+	if _, set := d.GetOk("evidence"); set {
+		params.Evidence = expandDisputeEvidence(d)
+	}
+	if submit, set := d.GetOk("submit"); set {
+		params.Submit = stripe.Bool(ToBool(submit))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	params.Context = ctx
+
+	dispute, err := c.Disputes.Update(ExtractString(d, "dispute"), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dispute.ID)
+	return resourceStripeDisputeRead(ctx, d, m)
+}
+
+func resourceStripeDisputeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.DisputeParams{}
+
+	if d.HasChange("evidence") {
+		params.Evidence = expandDisputeEvidence(d)
+	}
+	if d.HasChange("submit") {
+		params.Submit = stripe.Bool(ExtractBool(d, "submit"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Disputes.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeDisputeRead(ctx, d, m)
+}
+
+func resourceStripeDisputeDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("[WARN] Stripe SDK doesn't support Dispute deletion through API!")
+	d.SetId("")
+	return nil
+}
+
+func disputeEvidenceParamFields(evidence *stripe.DisputeEvidenceParams) map[string]**string {
+	return map[string]**string{
+		"access_activity_log":            &evidence.AccessActivityLog,
+		"billing_address":                &evidence.BillingAddress,
+		"cancellation_policy":            &evidence.CancellationPolicy,
+		"cancellation_policy_disclosure": &evidence.CancellationPolicyDisclosure,
+		"cancellation_rebuttal":          &evidence.CancellationRebuttal,
+		"customer_communication":         &evidence.CustomerCommunication,
+		"customer_email_address":         &evidence.CustomerEmailAddress,
+		"customer_name":                  &evidence.CustomerName,
+		"customer_purchase_ip":           &evidence.CustomerPurchaseIP,
+		"customer_signature":             &evidence.CustomerSignature,
+		"duplicate_charge_documentation": &evidence.DuplicateChargeDocumentation,
+		"duplicate_charge_explanation":   &evidence.DuplicateChargeExplanation,
+		"duplicate_charge_id":            &evidence.DuplicateChargeID,
+		"product_description":            &evidence.ProductDescription,
+		"receipt":                        &evidence.Receipt,
+		"refund_policy":                  &evidence.RefundPolicy,
+		"refund_policy_disclosure":       &evidence.RefundPolicyDisclosure,
+		"refund_refusal_explanation":     &evidence.RefundRefusalExplanation,
+		"service_date":                   &evidence.ServiceDate,
+		"service_documentation":          &evidence.ServiceDocumentation,
+		"shipping_address":               &evidence.ShippingAddress,
+		"shipping_carrier":               &evidence.ShippingCarrier,
+		"shipping_date":                  &evidence.ShippingDate,
+		"shipping_documentation":         &evidence.ShippingDocumentation,
+		"shipping_tracking_number":       &evidence.ShippingTrackingNumber,
+		"uncategorized_file":             &evidence.UncategorizedFile,
+		"uncategorized_text":             &evidence.UncategorizedText,
+	}
+}
+
+// expandDisputeEvidence sends the configured evidence fields and the changed ones, an emptied argument
+// clears the evidence at Stripe. Fields never set are left out, so evidence added in the dashboard stays.
+func expandDisputeEvidence(d *schema.ResourceData) *stripe.DisputeEvidenceParams {
+	evidence := &stripe.DisputeEvidenceParams{}
+	for name, field := range disputeEvidenceParamFields(evidence) {
+		key := "evidence.0." + name
+		if value := ExtractString(d, key); value != "" || d.HasChange(key) {
+			*field = stripe.String(value)
+		}
+	}
+	return evidence
+}
+
+func flattenDisputeEvidence(evidence *stripe.DisputeEvidence) []map[string]interface{} {
+	fileID := func(file *stripe.File) string {
+		if file == nil {
+			return ""
+		}
+		return file.ID
+	}
+
+	return []map[string]interface{}{
+		{
+			"access_activity_log":            evidence.AccessActivityLog,
+			"billing_address":                evidence.BillingAddress,
+			"cancellation_policy":            fileID(evidence.CancellationPolicy),
+			"cancellation_policy_disclosure": evidence.CancellationPolicyDisclosure,
+			"cancellation_rebuttal":          evidence.CancellationRebuttal,
+			"customer_communication":         fileID(evidence.CustomerCommunication),
+			"customer_email_address":         evidence.CustomerEmailAddress,
+			"customer_name":                  evidence.CustomerName,
+			"customer_purchase_ip":           evidence.CustomerPurchaseIP,
+			"customer_signature":             fileID(evidence.CustomerSignature),
+			"duplicate_charge_documentation": fileID(evidence.DuplicateChargeDocumentation),
+			"duplicate_charge_explanation":   evidence.DuplicateChargeExplanation,
+			"duplicate_charge_id":            evidence.DuplicateChargeID,
+			"product_description":            evidence.ProductDescription,
+			"receipt":                        fileID(evidence.Receipt),
+			"refund_policy":                  fileID(evidence.RefundPolicy),
+			"refund_policy_disclosure":       evidence.RefundPolicyDisclosure,
+			"refund_refusal_explanation":     evidence.RefundRefusalExplanation,
+			"service_date":                   evidence.ServiceDate,
+			"service_documentation":          fileID(evidence.ServiceDocumentation),
+			"shipping_address":               evidence.ShippingAddress,
+			"shipping_carrier":               evidence.ShippingCarrier,
+			"shipping_date":                  evidence.ShippingDate,
+			"shipping_documentation":         fileID(evidence.ShippingDocumentation),
+			"shipping_tracking_number":       evidence.ShippingTrackingNumber,
+			"uncategorized_file":             fileID(evidence.UncategorizedFile),
+			"uncategorized_text":             evidence.UncategorizedText,
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestResourceStripeDispute_evidence checks the evidence fields sent, only the configured and the changed
+// ones, so evidence uploaded in the dashboard isn't cleared.
+func TestResourceStripeDispute_evidence(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if r.Method == http.MethodPost {
+			requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "dp_123", "object": "dispute", "status": "needs_response", "evidence": {}}`)
+	})
+
+	config := &Config{API: api}
+	r := resourceStripeDispute()
+	apply := func(state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+		t.Helper()
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		state, diags := r.Apply(context.Background(), state, diff, config)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return state
+	}
+
+	state := apply(nil, map[string]interface{}{
+		"dispute": "dp_123",
+		"evidence": []interface{}{map[string]interface{}{
+			"customer_name": "Jenny Rosen",
+			"receipt":       "file_123",
+		}},
+	})
+	// the fake Stripe doesn't echo the evidence, keep the configured one in the state
+	state.Attributes["evidence.#"] = "1"
+	state.Attributes["evidence.0.customer_name"] = "Jenny Rosen"
+	state.Attributes["evidence.0.receipt"] = "file_123"
+	apply(state, map[string]interface{}{
+		"dispute": "dp_123",
+		"evidence": []interface{}{map[string]interface{}{
+			"customer_name": "Jenny Rosen-Smith",
+		}},
+	})
+
+	expected := []string{
+		"POST /v1/disputes/dp_123 map[evidence[customer_name]:[Jenny Rosen] evidence[receipt]:[file_123]]",
+		"POST /v1/disputes/dp_123 map[evidence[customer_name]:[Jenny Rosen-Smith] evidence[receipt]:[]]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
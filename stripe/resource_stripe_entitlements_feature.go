@@ -0,0 +1,205 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// stripe-go v72 predates entitlements, the features are sent through the backend directly
+type entitlementsFeatureParams struct {
+	stripe.Params `form:"*"`
+	Active        *bool   `form:"active"`
+	LookupKey     *string `form:"lookup_key"`
+	Name          *string `form:"name"`
+}
+
+type entitlementsFeatureListParams struct {
+	stripe.Params `form:"*"`
+	Archived      *bool   `form:"archived"`
+	LookupKey     *string `form:"lookup_key"`
+}
+
+type entitlementsFeature struct {
+	stripe.APIResource
+	ID        string            `json:"id"`
+	Active    bool              `json:"active"`
+	LookupKey string            `json:"lookup_key"`
+	Metadata  map[string]string `json:"metadata"`
+	Name      string            `json:"name"`
+}
+
+type entitlementsFeatureList struct {
+	stripe.APIResource
+	stripe.ListMeta
+	Data []*entitlementsFeature `json:"data"`
+}
+
+func resourceStripeEntitlementsFeature() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeEntitlementsFeatureRead,
+		CreateContext: resourceStripeEntitlementsFeatureCreate,
+		UpdateContext: resourceStripeEntitlementsFeatureUpdate,
+		DeleteContext: resourceStripeEntitlementsFeatureDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 80),
+				Description:  "The feature's name, for your own purpose, not meant to be displayable to the customer.",
+			},
+			"lookup_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 80),
+				Description: "A unique key you provide as your own system identifier. " +
+					"An archived feature with the same key is reactivated instead of creating a new one.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the feature is active, an inactive feature is archived and can't be attached to products.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+		},
+	}
+}
+
+func resourceStripeEntitlementsFeatureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	feature := &entitlementsFeature{}
+	path := stripe.FormatURLPath("/v1/entitlements/features/%s", d.Id())
+	err := rawCall(m, http.MethodGet, path, &stripe.Params{Context: ctx}, feature)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("name", feature.Name),
+		d.Set("lookup_key", feature.LookupKey),
+		d.Set("active", feature.Active),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(feature.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
+
+func resourceStripeEntitlementsFeatureCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lookupKey := ExtractString(d, "lookup_key")
+	params := &entitlementsFeatureParams{
+		LookupKey: stripe.String(lookupKey),
+		Name:      stripe.String(ExtractString(d, "name")),
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	feature := &entitlementsFeature{}
+	err := rawCall(m, http.MethodPost, "/v1/entitlements/features", params, feature)
+	var stripeErr *stripe.Error
+	if errors.As(err, &stripeErr) && stripeErr.Param == "lookup_key" {
+		// destroying a feature only archives it, the lookup key stays taken by the archived feature
+		archived, findErr := findArchivedEntitlementsFeature(ctx, m, lookupKey)
+		if findErr != nil {
+			return diag.FromErr(findErr)
+		}
+		if archived == nil {
+			return attributeErrorf("lookup_key", "a feature with lookup_key %q already exists, "+
+				"import it with `terraform import` to manage it", lookupKey)
+		}
+		log.Printf("[INFO] Reactivating the archived feature %s with lookup_key %q", archived.ID, lookupKey)
+		d.SetId(archived.ID)
+		return resourceStripeEntitlementsFeatureUpdate(ctx, d, m)
+	}
+	if err != nil {
+		return diagFromStripeErr(err)
+	}
+
+	// a new feature is active, archiving it is an update
+	d.SetId(feature.ID)
+	if !ExtractBool(d, "active") {
+		return resourceStripeEntitlementsFeatureUpdate(ctx, d, m)
+	}
+	return resourceStripeEntitlementsFeatureRead(ctx, d, m)
+}
+
+func resourceStripeEntitlementsFeatureUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &entitlementsFeatureParams{
+		Active: stripe.Bool(ExtractBool(d, "active")),
+	}
+	if d.HasChange("name") {
+		params.Name = stripe.String(ExtractString(d, "name"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	path := stripe.FormatURLPath("/v1/entitlements/features/%s", d.Id())
+	err := rawCall(m, http.MethodPost, path, params, &entitlementsFeature{})
+	if err != nil {
+		return diagFromStripeErr(err)
+	}
+
+	return resourceStripeEntitlementsFeatureRead(ctx, d, m)
+}
+
+// resourceStripeEntitlementsFeatureDelete archives the feature, Stripe doesn't delete them.
+func resourceStripeEntitlementsFeatureDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &entitlementsFeatureParams{
+		Active: stripe.Bool(false),
+	}
+	params.Context = ctx
+	path := stripe.FormatURLPath("/v1/entitlements/features/%s", d.Id())
+	err := rawCall(m, http.MethodPost, path, params, &entitlementsFeature{})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// findArchivedEntitlementsFeature returns the archived feature having the lookup key, nil when it isn't archived.
+func findArchivedEntitlementsFeature(ctx context.Context, m interface{}, lookupKey string) (*entitlementsFeature, error) {
+	params := &entitlementsFeatureListParams{
+		Archived:  stripe.Bool(true),
+		LookupKey: stripe.String(lookupKey),
+	}
+	params.Context = ctx
+	list := &entitlementsFeatureList{}
+	if err := rawCall(m, http.MethodGet, "/v1/entitlements/features", params, list); err != nil {
+		return nil, fmt.Errorf("can't look up the feature with lookup_key %q: %w", lookupKey, err)
+	}
+	if len(list.Data) == 0 {
+		return nil, nil
+	}
+	return list.Data[0], nil
+}
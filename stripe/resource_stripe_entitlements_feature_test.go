@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestResourceStripeEntitlementsFeature_lookupKeyTaken checks the create of a feature whose lookup_key
+// is already taken: the archived feature left by a destroy is reactivated, an active one has to be imported.
+func TestResourceStripeEntitlementsFeature_lookupKeyTaken(t *testing.T) {
+	testCases := map[string]struct {
+		archived string
+		expected []string
+		err      string
+	}{
+		"archived": {
+			archived: `{"id": "feat_123", "object": "entitlements.feature", "active": false, "lookup_key": "seats", "name": "Old"}`,
+			expected: []string{
+				"POST /v1/entitlements/features map[lookup_key:[seats] name:[Seats]]",
+				"GET /v1/entitlements/features map[archived:[true] lookup_key:[seats]]",
+				"POST /v1/entitlements/features/feat_123 map[active:[true] name:[Seats]]",
+				"GET /v1/entitlements/features/feat_123 map[]",
+			},
+		},
+		"active": {
+			expected: []string{
+				"POST /v1/entitlements/features map[lookup_key:[seats] name:[Seats]]",
+				"GET /v1/entitlements/features map[archived:[true] lookup_key:[seats]]",
+			},
+			err: `a feature with lookup_key "seats" already exists, import it with ` + "`terraform import`" + ` to manage it`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.Form))
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/entitlements/features":
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "param": "lookup_key",
+  "message": "A feature with lookup_key seats already exists."}}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/entitlements/features":
+					fmt.Fprintf(w, `{"object": "list", "data": [%s]}`, testCase.archived)
+				default:
+					fmt.Fprint(w, `{"id": "feat_123", "object": "entitlements.feature", "active": true, "lookup_key": "seats", "name": "Seats"}`)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceStripeEntitlementsFeature().Schema, map[string]interface{}{
+				"name":            "Seats",
+				"lookup_key":      "seats",
+				"idempotency_key": "test",
+			})
+			diags := resourceStripeEntitlementsFeatureCreate(context.Background(), d, &Config{API: api})
+			if testCase.err == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				if d.Id() != "feat_123" || !ExtractBool(d, "active") {
+					t.Errorf("expected the archived feature feat_123 reactivated, got %q active %t", d.Id(), ExtractBool(d, "active"))
+				}
+			} else {
+				if !diags.HasError() || diags[0].Summary != testCase.err {
+					t.Fatalf("expected the error %q, got %v", testCase.err, diags)
+				}
+				if !diags[0].AttributePath.Equals(cty.GetAttrPath("lookup_key")) {
+					t.Errorf("expected the error on lookup_key, got %#v", diags[0].AttributePath)
+				}
+			}
+			if fmt.Sprint(requests) != fmt.Sprint(testCase.expected) {
+				t.Errorf("expected requests %v, got %v", testCase.expected, requests)
+			}
+		})
+	}
+}
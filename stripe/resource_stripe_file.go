@@ -0,0 +1,123 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeFileRead,
+		CreateContext: resourceStripeFileCreate,
+		DeleteContext: resourceStripeFileDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"file_path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path to the local file which is uploaded to Stripe.",
+			},
+			"purpose": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The purpose of the uploaded file, " +
+					"e.g. business_icon, business_logo, customer_signature, dispute_evidence or identity_document.",
+			},
+			"filename": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A filename for the file, suitable for saving to a filesystem.",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size in bytes of the file object.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the file returned (e.g., csv, pdf, jpg, or png).",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL from which the file can be downloaded using your live secret API key.",
+			},
+			"created": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Time at which the object was created. Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+func resourceStripeFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	file, err := c.Files.Get(d.Id(), &stripe.FileParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("purpose", file.Purpose),
+		d.Set("filename", file.Filename),
+		d.Set("size", file.Size),
+		d.Set("type", file.Type),
+		d.Set("url", file.URL),
+		d.Set("created", file.Created),
+	)
+}
+
+func resourceStripeFileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	filePath := ExtractString(d, "file_path")
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Can't open the file to upload",
+			Detail:   err.Error(),
+		}}
+	}
+	defer f.Close()
+
+	params := &stripe.FileParams{
+		FileReader: f,
+		Filename:   stripe.String(filepath.Base(filePath)),
+		Purpose:    stripe.String(ExtractString(d, "purpose")),
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	file, err := c.Files.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(file.ID)
+	return resourceStripeFileRead(ctx, d, m)
+}
+
+func resourceStripeFileDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("[WARN] Stripe SDK doesn't support File deletion through API!")
+	d.SetId("")
+	return nil
+}
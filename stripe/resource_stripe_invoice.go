@@ -0,0 +1,266 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeInvoice() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeInvoiceRead,
+		CreateContext: resourceStripeInvoiceCreate,
+		UpdateContext: resourceStripeInvoiceUpdate,
+		DeleteContext: resourceStripeInvoiceDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer who will be billed.",
+			},
+			"collection_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.InvoiceCollectionMethodChargeAutomatically),
+					string(stripe.InvoiceCollectionMethodSendInvoice),
+				}, false),
+				Description: "Either charge_automatically, or send_invoice. " +
+					"Defaults to charge_automatically.",
+			},
+			"auto_advance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Controls whether Stripe will perform automatic collection of the invoice. " +
+					"When false, the invoice’s state will not automatically advance without an explicit action.",
+			},
+			"finalize": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Finalizes the draft invoice when set to true, so it can be paid. " +
+					"Finalization can't be undone, setting it back to false has no effect.",
+			},
+			"days_until_due": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "The number of days from when the invoice is created until it is due. " +
+					"Valid only for invoices where collection_method=send_invoice.",
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "An arbitrary string attached to the object. " +
+					"Often useful for displaying to users. Referenced as ‘memo’ in the Dashboard.",
+			},
+			"footer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Footer to be displayed on the invoice.",
+			},
+			"default_tax_rates": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The tax rates that will apply to any line item " +
+					"that does not have tax_rates set.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the invoice, one of draft, open, paid, " +
+					"uncollectible, or void.",
+			},
+			"hosted_invoice_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The URL for the hosted invoice page, which allows customers to view and pay an invoice. " +
+					"Empty until the invoice is finalized.",
+			},
+			"invoice_pdf": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The link to download the PDF for the invoice. " +
+					"Empty until the invoice is finalized.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total after discounts and taxes.",
+			},
+		},
+	}
+}
+
+func resourceStripeInvoiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	invoice, err := c.Invoices.Get(d.Id(), &stripe.InvoiceParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("customer", invoice.Customer.ID),
+		func() error {
+			if invoice.CollectionMethod != nil {
+				return d.Set("collection_method", *invoice.CollectionMethod)
+			}
+			return nil
+		}(),
+		d.Set("auto_advance", invoice.AutoAdvance),
+		d.Set("description", invoice.Description),
+		d.Set("footer", invoice.Footer),
+		func() error {
+			var taxRates []string
+			for _, taxRate := range invoice.DefaultTaxRates {
+				taxRates = append(taxRates, taxRate.ID)
+			}
+			return d.Set("default_tax_rates", taxRates)
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(invoice.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", invoice.Status),
+		d.Set("hosted_invoice_url", invoice.HostedInvoiceURL),
+		d.Set("invoice_pdf", invoice.InvoicePDF),
+		d.Set("total", invoice.Total),
+	)
+}
+
+func resourceStripeInvoiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.InvoiceParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+
+	if collectionMethod, set := d.GetOk("collection_method"); set {
+		params.CollectionMethod = stripe.String(ToString(collectionMethod))
+	}
+	if autoAdvance, set := d.GetOkExists("auto_advance"); set {
+		params.AutoAdvance = stripe.Bool(ToBool(autoAdvance))
+	}
+	if daysUntilDue, set := d.GetOk("days_until_due"); set {
+		params.DaysUntilDue = stripe.Int64(ToInt64(daysUntilDue))
+	}
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	if footer, set := d.GetOk("footer"); set {
+		params.Footer = stripe.String(ToString(footer))
+	}
+	if taxRates, set := d.GetOk("default_tax_rates"); set {
+		params.DefaultTaxRates = stripe.StringSlice(ToStringSlice(taxRates))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	invoice, err := c.Invoices.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(invoice.ID)
+	if ExtractBool(d, "finalize") {
+		if _, err := c.Invoices.FinalizeInvoice(invoice.ID, &stripe.InvoiceFinalizeParams{Params: stripe.Params{Context: ctx}}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceStripeInvoiceRead(ctx, d, m)
+}
+
+func resourceStripeInvoiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.InvoiceParams{}
+
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(ExtractString(d, "collection_method"))
+	}
+	if d.HasChange("auto_advance") {
+		params.AutoAdvance = stripe.Bool(ExtractBool(d, "auto_advance"))
+	}
+	if d.HasChange("days_until_due") {
+		params.DaysUntilDue = stripe.Int64(ExtractInt64(d, "days_until_due"))
+	}
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("footer") {
+		params.Footer = stripe.String(ExtractString(d, "footer"))
+	}
+	if d.HasChange("default_tax_rates") {
+		params.DefaultTaxRates = stripe.StringSlice(ExtractStringSlice(d, "default_tax_rates"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	if d.HasChanges("collection_method", "auto_advance", "days_until_due", "description", "footer",
+		"default_tax_rates", "metadata") {
+		params.Context = ctx
+		_, err := c.Invoices.Update(d.Id(), params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// the draft is finalized after the update, its fields can't all be changed anymore once it's open
+	if d.HasChange("finalize") && ExtractBool(d, "finalize") && ExtractString(d, "status") == string(stripe.InvoiceStatusDraft) {
+		_, err := c.Invoices.FinalizeInvoice(d.Id(), &stripe.InvoiceFinalizeParams{Params: stripe.Params{Context: ctx}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeInvoiceRead(ctx, d, m)
+}
+
+func resourceStripeInvoiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	// the status in the state may be outdated, e.g. when the invoice was finalized automatically
+	invoice, err := c.Invoices.Get(d.Id(), &stripe.InvoiceParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	switch invoice.Status {
+	case stripe.InvoiceStatusDraft:
+		_, err = c.Invoices.Del(d.Id(), &stripe.InvoiceParams{Params: stripe.Params{Context: ctx}})
+	case stripe.InvoiceStatusOpen, stripe.InvoiceStatusUncollectible:
+		_, err = c.Invoices.VoidInvoice(d.Id(), &stripe.InvoiceVoidParams{Params: stripe.Params{Context: ctx}})
+	default:
+		log.Printf("[WARN] Invoice %s is %s and can't be deleted or voided, removing it from the state",
+			d.Id(), invoice.Status)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
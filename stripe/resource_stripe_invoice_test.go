@@ -0,0 +1,69 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeInvoice_finalize(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeInvoiceConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_invoice.test", "status", "draft"),
+					// an invoice without lines is paid right away when it's finalized
+					testAccAddStripeInvoiceItem("stripe_invoice.test", 1500),
+				),
+			},
+			{
+				Config: testAccStripeInvoiceConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_invoice.test", "status", "open"),
+					resource.TestCheckResourceAttr("stripe_invoice.test", "total", "1500"),
+					resource.TestCheckResourceAttrSet("stripe_invoice.test", "hosted_invoice_url"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAddStripeInvoiceItem(name string, amount int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		c := testAccProvider.Meta().(*Config).API
+		_, err := c.InvoiceItems.New(&stripe.InvoiceItemParams{
+			Customer: stripe.String(rs.Primary.Attributes["customer"]),
+			Invoice:  stripe.String(rs.Primary.ID),
+			Amount:   stripe.Int64(amount),
+			Currency: stripe.String(string(stripe.CurrencyUSD)),
+		})
+		return err
+	}
+}
+
+func testAccStripeInvoiceConfig(finalize bool) string {
+	return fmt.Sprintf(`
+resource "stripe_customer" "test" {
+  name = "Invoice finalization test"
+}
+
+resource "stripe_invoice" "test" {
+  customer          = stripe_customer.test.id
+  collection_method = "send_invoice"
+  days_until_due    = 30
+  auto_advance      = false
+  finalize          = %t
+}
+`, finalize)
+}
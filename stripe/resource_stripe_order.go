@@ -0,0 +1,484 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// ordersBetaVersion opts the requests into the Orders API beta, without it Stripe serves the legacy API
+// on the same paths. The beta is announced in the Stripe-Version header, after the API version.
+const ordersBetaVersion = "orders_beta=v4"
+
+// withOrdersBeta adds the Orders API beta to the Stripe-Version header of the request.
+func withOrdersBeta(params *stripe.Params) {
+	if params.Headers == nil {
+		params.Headers = http.Header{}
+	}
+	params.Headers.Set("Stripe-Version", stripe.APIVersion+"; "+ordersBetaVersion)
+}
+
+// the orders client of stripe-go v72 targets the legacy Orders API, which has no line items,
+// automatic tax or cancellation, so the requests are sent through its backend directly
+type orderLineItemParams struct {
+	Description *string `form:"description"`
+	Price       *string `form:"price"`
+	Product     *string `form:"product"`
+	Quantity    *int64  `form:"quantity"`
+}
+
+type orderShippingDetailsParams struct {
+	Address *stripe.AddressParams `form:"address"`
+	Name    *string               `form:"name"`
+	Phone   *string               `form:"phone"`
+}
+
+type orderBillingDetailsParams struct {
+	Address *stripe.AddressParams `form:"address"`
+	Email   *string               `form:"email"`
+	Name    *string               `form:"name"`
+	Phone   *string               `form:"phone"`
+}
+
+type orderAutomaticTaxParams struct {
+	Enabled *bool `form:"enabled"`
+}
+
+type orderParams struct {
+	stripe.Params   `form:"*"`
+	AutomaticTax    *orderAutomaticTaxParams    `form:"automatic_tax"`
+	BillingDetails  *orderBillingDetailsParams  `form:"billing_details"`
+	Currency        *string                     `form:"currency"`
+	Customer        *string                     `form:"customer"`
+	LineItems       []*orderLineItemParams      `form:"line_items"`
+	ShippingDetails *orderShippingDetailsParams `form:"shipping_details"`
+}
+
+type orderLineItem struct {
+	Description string `json:"description"`
+	Price       *struct {
+		ID string `json:"id"`
+	} `json:"price"`
+	Product  string `json:"product"`
+	Quantity int64  `json:"quantity"`
+}
+
+type order struct {
+	stripe.APIResource
+	ID           string `json:"id"`
+	AmountTotal  int64  `json:"amount_total"`
+	AutomaticTax *struct {
+		Enabled bool   `json:"enabled"`
+		Status  string `json:"status"`
+	} `json:"automatic_tax"`
+	BillingDetails *struct {
+		Address *stripe.Address `json:"address"`
+		Email   string          `json:"email"`
+		Name    string          `json:"name"`
+		Phone   string          `json:"phone"`
+	} `json:"billing_details"`
+	ClientSecret string           `json:"client_secret"`
+	Currency     stripe.Currency  `json:"currency"`
+	Customer     *stripe.Customer `json:"customer"`
+	LineItems    *struct {
+		Data []*orderLineItem `json:"data"`
+	} `json:"line_items"`
+	Metadata        map[string]string `json:"metadata"`
+	ShippingDetails *struct {
+		Address *stripe.Address `json:"address"`
+		Name    string          `json:"name"`
+		Phone   string          `json:"phone"`
+	} `json:"shipping_details"`
+	Status string `json:"status"`
+}
+
+func resourceStripeOrder() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeOrderRead,
+		CreateContext: resourceStripeOrderCreate,
+		UpdateContext: resourceStripeOrderUpdate,
+		DeleteContext: resourceStripeOrderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"currency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The customer associated with this order.",
+			},
+			"line_items": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The products and prices the customer is ordering.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"price": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The ID of the price of the line item.",
+						},
+						"product": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The ID of the product of the line item.",
+						},
+						"quantity": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "The quantity of the line item, defaults to 1.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The description of the line item, defaults to the product name.",
+						},
+					},
+				},
+			},
+			"shipping_details": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Shipping map with fields like name, phone and fields related to the address: " +
+					"line1, line2, city, state, postal_code and country.",
+			},
+			"billing_details": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Billing map with fields like name, email, phone and fields related to the address: " +
+					"line1, line2, city, state, postal_code and country.",
+			},
+			"automatic_tax": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Settings for automatic tax calculation of the order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether Stripe automatically computes tax on this order.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the most recent automated tax calculation for this order.",
+						},
+					},
+				},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the order, one of open, submitted, processing, " +
+					"complete or canceled.",
+			},
+			"amount_total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total of the order after discounts and taxes, in the smallest currency unit.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The client secret used client-side to submit and pay the order.",
+			},
+		},
+	}
+}
+
+func resourceStripeOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	o, err := getOrder(ctx, m, d.Id())
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("currency", o.Currency),
+		func() error {
+			if o.Customer != nil {
+				return d.Set("customer", o.Customer.ID)
+			}
+			return d.Set("customer", "")
+		}(),
+		func() error {
+			var lineItems []map[string]interface{}
+			if o.LineItems != nil {
+				for _, item := range o.LineItems.Data {
+					lineItem := map[string]interface{}{
+						"product":     item.Product,
+						"quantity":    item.Quantity,
+						"description": item.Description,
+					}
+					if item.Price != nil {
+						lineItem["price"] = item.Price.ID
+					}
+					lineItems = append(lineItems, lineItem)
+				}
+			}
+			return d.Set("line_items", lineItems)
+		}(),
+		func() error {
+			if o.ShippingDetails != nil {
+				shippingMap := flattenAddressMap(o.ShippingDetails.Address)
+				if o.ShippingDetails.Name != "" {
+					shippingMap["name"] = o.ShippingDetails.Name
+				}
+				if o.ShippingDetails.Phone != "" {
+					shippingMap["phone"] = o.ShippingDetails.Phone
+				}
+				return d.Set("shipping_details", shippingMap)
+			}
+			return d.Set("shipping_details", nil)
+		}(),
+		func() error {
+			if o.BillingDetails != nil {
+				billingMap := flattenAddressMap(o.BillingDetails.Address)
+				if o.BillingDetails.Name != "" {
+					billingMap["name"] = o.BillingDetails.Name
+				}
+				if o.BillingDetails.Email != "" {
+					billingMap["email"] = o.BillingDetails.Email
+				}
+				if o.BillingDetails.Phone != "" {
+					billingMap["phone"] = o.BillingDetails.Phone
+				}
+				return d.Set("billing_details", billingMap)
+			}
+			return d.Set("billing_details", nil)
+		}(),
+		func() error {
+			if o.AutomaticTax != nil {
+				return d.Set("automatic_tax", []map[string]interface{}{
+					{
+						"enabled": o.AutomaticTax.Enabled,
+						"status":  o.AutomaticTax.Status,
+					},
+				})
+			}
+			return nil
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(o.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", o.Status),
+		d.Set("amount_total", o.AmountTotal),
+		d.Set("client_secret", o.ClientSecret),
+	)
+}
+
+func resourceStripeOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &orderParams{
+		Currency:  stripe.String(ExtractString(d, "currency")),
+		LineItems: expandOrderLineItems(d.Get("line_items")),
+	}
+
+	if customer, set := d.GetOk("customer"); set {
+		params.Customer = stripe.String(ToString(customer))
+	}
+	if shippingDetails, set := d.GetOk("shipping_details"); set {
+		params.ShippingDetails = expandOrderShippingDetails(shippingDetails)
+	}
+	if billingDetails, set := d.GetOk("billing_details"); set {
+		params.BillingDetails = expandOrderBillingDetails(billingDetails)
+	}
+	if automaticTax, set := d.GetOk("automatic_tax"); set {
+		params.AutomaticTax = expandOrderAutomaticTax(automaticTax)
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	withOrdersBeta(&params.Params)
+	params.Context = ctx
+	o := &order{}
+	err := rawCall(m, http.MethodPost, "/v1/orders", params, o)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(o.ID)
+	return resourceStripeOrderRead(ctx, d, m)
+}
+
+func resourceStripeOrderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &orderParams{}
+
+	if !d.HasChanges("customer", "line_items", "shipping_details", "billing_details", "automatic_tax", "metadata") {
+		return resourceStripeOrderRead(ctx, d, m)
+	}
+
+	if d.HasChange("customer") {
+		params.Customer = stripe.String(ExtractString(d, "customer"))
+	}
+	if d.HasChange("line_items") {
+		// the items sent replace the ones of the order
+		params.LineItems = expandOrderLineItems(d.Get("line_items"))
+	}
+	if d.HasChange("shipping_details") {
+		if shippingDetails, set := d.GetOk("shipping_details"); set {
+			params.ShippingDetails = expandOrderShippingDetails(shippingDetails)
+		} else {
+			params.AddExtra("shipping_details", "")
+		}
+	}
+	if d.HasChange("billing_details") {
+		if billingDetails, set := d.GetOk("billing_details"); set {
+			params.BillingDetails = expandOrderBillingDetails(billingDetails)
+		} else {
+			params.AddExtra("billing_details", "")
+		}
+	}
+	if d.HasChange("automatic_tax") {
+		params.AutomaticTax = expandOrderAutomaticTax(d.Get("automatic_tax"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	withOrdersBeta(&params.Params)
+	params.Context = ctx
+	path := stripe.FormatURLPath("/v1/orders/%s", d.Id())
+	err := rawCall(m, http.MethodPost, path, params, &order{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeOrderRead(ctx, d, m)
+}
+
+func resourceStripeOrderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// the status in the state may be outdated, e.g. when the order was submitted client-side
+	o, err := getOrder(ctx, m, d.Id())
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	switch o.Status {
+	case "open", "submitted":
+		params := &stripe.Params{}
+		withOrdersBeta(params)
+		params.Context = ctx
+		path := stripe.FormatURLPath("/v1/orders/%s/cancel", d.Id())
+		err = rawCall(m, http.MethodPost, path, params, &order{})
+	default:
+		log.Printf("[WARN] Order %s is %s and can't be canceled, removing it from the state", d.Id(), o.Status)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getOrder fetches the order with its line items, which the API leaves out unless expanded.
+func getOrder(ctx context.Context, m interface{}, id string) (*order, error) {
+	params := &stripe.Params{}
+	params.AddExpand("line_items")
+	withOrdersBeta(params)
+	params.Context = ctx
+	o := &order{}
+	path := stripe.FormatURLPath("/v1/orders/%s", id)
+	return o, rawCall(m, http.MethodGet, path, params, o)
+}
+
+func expandOrderLineItems(value interface{}) []*orderLineItemParams {
+	var lineItems []*orderLineItemParams
+	for _, item := range ToSlice(value) {
+		itemMap := ToMap(item)
+		lineItem := &orderLineItemParams{}
+		if price := ToString(itemMap["price"]); price != "" {
+			lineItem.Price = stripe.String(price)
+		}
+		if product := ToString(itemMap["product"]); product != "" {
+			lineItem.Product = stripe.String(product)
+		}
+		if quantity := ToInt64(itemMap["quantity"]); quantity != 0 {
+			lineItem.Quantity = stripe.Int64(quantity)
+		}
+		if description := ToString(itemMap["description"]); description != "" {
+			lineItem.Description = stripe.String(description)
+		}
+		lineItems = append(lineItems, lineItem)
+	}
+	return lineItems
+}
+
+func expandOrderShippingDetails(value interface{}) *orderShippingDetailsParams {
+	shippingMap := ToMap(value)
+	shippingDetails := &orderShippingDetailsParams{
+		Address: expandAddress(shippingMap),
+	}
+	if name, set := shippingMap["name"]; set {
+		shippingDetails.Name = stripe.String(ToString(name))
+	}
+	if phone, set := shippingMap["phone"]; set {
+		shippingDetails.Phone = stripe.String(ToString(phone))
+	}
+	return shippingDetails
+}
+
+func expandOrderBillingDetails(value interface{}) *orderBillingDetailsParams {
+	billingMap := ToMap(value)
+	billingDetails := &orderBillingDetailsParams{
+		Address: expandAddress(billingMap),
+	}
+	if name, set := billingMap["name"]; set {
+		billingDetails.Name = stripe.String(ToString(name))
+	}
+	if email, set := billingMap["email"]; set {
+		billingDetails.Email = stripe.String(ToString(email))
+	}
+	if phone, set := billingMap["phone"]; set {
+		billingDetails.Phone = stripe.String(ToString(phone))
+	}
+	return billingDetails
+}
+
+// expandOrderAutomaticTax disables automatic tax when the block is removed.
+func expandOrderAutomaticTax(value interface{}) *orderAutomaticTaxParams {
+	automaticTax := &orderAutomaticTaxParams{Enabled: stripe.Bool(false)}
+	for _, block := range ToSlice(value) {
+		automaticTax.Enabled = stripe.Bool(ToBool(ToMap(block)["enabled"]))
+	}
+	return automaticTax
+}
@@ -0,0 +1,47 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// TestResourceStripeOrder_ordersBeta checks that every order request opts into the Orders API beta,
+// without it Stripe answers with the legacy orders.
+func TestResourceStripeOrder_ordersBeta(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if version := r.Header.Get("Stripe-Version"); version != stripe.APIVersion+"; orders_beta=v4" {
+			t.Errorf("%s %s sent Stripe-Version %q", r.Method, r.URL.Path, version)
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "order_123", "object": "order", "currency": "usd", "status": "open"}`)
+	})
+
+	config := &Config{API: api}
+	d := schema.TestResourceDataRaw(t, resourceStripeOrder().Schema, map[string]interface{}{
+		"currency":   "usd",
+		"line_items": []interface{}{map[string]interface{}{"price": "price_123"}},
+	})
+	if diags := resourceStripeOrderCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags := resourceStripeOrderDelete(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		"POST /v1/orders",
+		"GET /v1/orders/order_123",
+		"GET /v1/orders/order_123",
+		"POST /v1/orders/order_123/cancel",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,238 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripePaymentIntent() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePaymentIntentRead,
+		CreateContext: resourceStripePaymentIntentCreate,
+		UpdateContext: resourceStripePaymentIntentUpdate,
+		DeleteContext: resourceStripePaymentIntentDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"amount": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Amount intended to be collected by this payment intent, in the smallest currency unit.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the customer this payment intent belongs to, if one exists.",
+			},
+			"payment_method": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the payment method used in this payment intent.",
+			},
+			"payment_method_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The list of payment method types that this payment intent is allowed to use. " +
+					"Defaults to card.",
+			},
+			"capture_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.PaymentIntentCaptureMethodAutomatic),
+					string(stripe.PaymentIntentCaptureMethodManual),
+				}, false),
+				Description: "Controls when the funds will be captured from the customer’s account, " +
+					"either automatic or manual. Defaults to automatic.",
+			},
+			"confirm": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+				Description: "Attempt to confirm this payment intent immediately on creation, " +
+					"which requires the payment_method to be set.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary string attached to the object. Often useful for displaying to users.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Status of this payment intent, one of requires_payment_method, requires_confirmation, " +
+					"requires_action, processing, requires_capture, canceled, or succeeded.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The client secret of this payment intent, used on the client to complete the payment.",
+			},
+		},
+	}
+}
+
+func resourceStripePaymentIntentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	paymentIntent, err := c.PaymentIntents.Get(d.Id(), &stripe.PaymentIntentParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("amount", paymentIntent.Amount),
+		d.Set("currency", paymentIntent.Currency),
+		func() error {
+			if paymentIntent.Customer != nil {
+				return d.Set("customer", paymentIntent.Customer.ID)
+			}
+			return d.Set("customer", "")
+		}(),
+		func() error {
+			if paymentIntent.PaymentMethod != nil {
+				return d.Set("payment_method", paymentIntent.PaymentMethod.ID)
+			}
+			return d.Set("payment_method", "")
+		}(),
+		d.Set("payment_method_types", paymentIntent.PaymentMethodTypes),
+		d.Set("capture_method", paymentIntent.CaptureMethod),
+		d.Set("description", paymentIntent.Description),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(paymentIntent.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", paymentIntent.Status),
+		d.Set("client_secret", paymentIntent.ClientSecret),
+	)
+}
+
+func resourceStripePaymentIntentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PaymentIntentParams{
+		Amount:   stripe.Int64(ExtractInt64(d, "amount")),
+		Currency: stripe.String(ExtractString(d, "currency")),
+	}
+
+	if customer, set := d.GetOk("customer"); set {
+		params.Customer = stripe.String(ToString(customer))
+	}
+	if paymentMethod, set := d.GetOk("payment_method"); set {
+		params.PaymentMethod = stripe.String(ToString(paymentMethod))
+	}
+	if paymentMethodTypes, set := d.GetOk("payment_method_types"); set {
+		params.PaymentMethodTypes = stripe.StringSlice(ToStringSlice(paymentMethodTypes))
+	}
+	if captureMethod, set := d.GetOk("capture_method"); set {
+		params.CaptureMethod = stripe.String(ToString(captureMethod))
+	}
+	if confirm, set := d.GetOk("confirm"); set {
+		params.Confirm = stripe.Bool(ToBool(confirm))
+	}
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	paymentIntent, err := c.PaymentIntents.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(paymentIntent.ID)
+	return resourceStripePaymentIntentRead(ctx, d, m)
+}
+
+func resourceStripePaymentIntentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PaymentIntentParams{}
+
+	// amount and currency are ForceNew, a payment intent keeps what it was created with
+	if d.HasChange("customer") {
+		params.Customer = stripe.String(ExtractString(d, "customer"))
+	}
+	if d.HasChange("payment_method") {
+		params.PaymentMethod = stripe.String(ExtractString(d, "payment_method"))
+	}
+	if d.HasChange("payment_method_types") {
+		params.PaymentMethodTypes = stripe.StringSlice(ExtractStringSlice(d, "payment_method_types"))
+	}
+	if d.HasChange("capture_method") {
+		params.CaptureMethod = stripe.String(ExtractString(d, "capture_method"))
+	}
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.PaymentIntents.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripePaymentIntentRead(ctx, d, m)
+}
+
+func resourceStripePaymentIntentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	// the status in the state may be outdated, e.g. when the customer completed the payment
+	paymentIntent, err := c.PaymentIntents.Get(d.Id(), &stripe.PaymentIntentParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	switch paymentIntent.Status {
+	case stripe.PaymentIntentStatusRequiresPaymentMethod,
+		stripe.PaymentIntentStatusRequiresConfirmation,
+		stripe.PaymentIntentStatusRequiresAction,
+		stripe.PaymentIntentStatusRequiresCapture:
+		_, err = c.PaymentIntents.Cancel(d.Id(), &stripe.PaymentIntentCancelParams{Params: stripe.Params{Context: ctx}})
+	default:
+		log.Printf("[WARN] Payment Intent %s is %s and can't be canceled, removing it from the state",
+			d.Id(), paymentIntent.Status)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
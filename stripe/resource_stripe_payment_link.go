@@ -0,0 +1,335 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// stripe-go v72 predates payment links, they're sent through the backend directly
+type paymentLinkLineItemParams struct {
+	Price    *string `form:"price"`
+	Quantity *int64  `form:"quantity"`
+}
+
+type paymentLinkHostedConfirmationParams struct {
+	CustomMessage *string `form:"custom_message"`
+}
+
+type paymentLinkRedirectParams struct {
+	URL *string `form:"url"`
+}
+
+type paymentLinkAfterCompletionParams struct {
+	HostedConfirmation *paymentLinkHostedConfirmationParams `form:"hosted_confirmation"`
+	Redirect           *paymentLinkRedirectParams           `form:"redirect"`
+	Type               *string                              `form:"type"`
+}
+
+type paymentLinkParams struct {
+	stripe.Params            `form:"*"`
+	Active                   *bool                             `form:"active"`
+	AfterCompletion          *paymentLinkAfterCompletionParams `form:"after_completion"`
+	AllowPromotionCodes      *bool                             `form:"allow_promotion_codes"`
+	BillingAddressCollection *string                           `form:"billing_address_collection"`
+	Currency                 *string                           `form:"currency"`
+	LineItems                []*paymentLinkLineItemParams      `form:"line_items"`
+}
+
+type paymentLink struct {
+	stripe.APIResource
+	ID              string `json:"id"`
+	Active          bool   `json:"active"`
+	AfterCompletion *struct {
+		HostedConfirmation *struct {
+			CustomMessage string `json:"custom_message"`
+		} `json:"hosted_confirmation"`
+		Redirect *struct {
+			URL string `json:"url"`
+		} `json:"redirect"`
+		Type string `json:"type"`
+	} `json:"after_completion"`
+	AllowPromotionCodes      bool                 `json:"allow_promotion_codes"`
+	BillingAddressCollection string               `json:"billing_address_collection"`
+	Currency                 stripe.Currency      `json:"currency"`
+	LineItems                *stripe.LineItemList `json:"line_items"`
+	Metadata                 map[string]string    `json:"metadata"`
+	URL                      string               `json:"url"`
+}
+
+func resourceStripePaymentLink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePaymentLinkRead,
+		CreateContext: resourceStripePaymentLinkCreate,
+		UpdateContext: resourceStripePaymentLinkUpdate,
+		DeleteContext: resourceStripePaymentLinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"line_items": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The line items representing what is being sold.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"price": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The ID of the price object.",
+						},
+						"quantity": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The quantity of the line item being purchased.",
+						},
+					},
+				},
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the payment link's url is active, customers can't pay through an inactive link.",
+			},
+			"after_completion": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Behavior after the purchase is complete.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"hosted_confirmation",
+								"redirect",
+							}, false),
+							Description: "The specified behavior after the purchase is complete, " +
+								"either hosted_confirmation or redirect.",
+						},
+						"hosted_confirmation_custom_message": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A custom message to display to the customer after the purchase is complete.",
+						},
+						"redirect_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL the customer is redirected to after the purchase is complete.",
+						},
+					},
+				},
+			},
+			"allow_promotion_codes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enables user redeemable promotion codes.",
+			},
+			"billing_address_collection": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "auto",
+				ValidateFunc: validation.StringInSlice([]string{
+					"auto",
+					"required",
+				}, false),
+				Description: "Configuration for collecting the customer's billing address, either auto or required.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description: "Three-letter ISO currency code, in lowercase. " +
+					"Defaults to the currency of the prices, it must be supported by each of them.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public URL that can be shared with customers.",
+			},
+		},
+	}
+}
+
+func resourceStripePaymentLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &stripe.Params{Context: ctx}
+	params.AddExpand("line_items")
+	link := &paymentLink{}
+	path := stripe.FormatURLPath("/v1/payment_links/%s", d.Id())
+	err := rawCall(m, http.MethodGet, path, params, link)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		func() error {
+			var lineItems []map[string]interface{}
+			if link.LineItems != nil {
+				for _, item := range link.LineItems.Data {
+					lineItem := map[string]interface{}{
+						"quantity": item.Quantity,
+					}
+					if item.Price != nil {
+						lineItem["price"] = item.Price.ID
+					}
+					lineItems = append(lineItems, lineItem)
+				}
+			}
+			return d.Set("line_items", lineItems)
+		}(),
+		d.Set("active", link.Active),
+		func() error {
+			if link.AfterCompletion == nil {
+				return d.Set("after_completion", nil)
+			}
+			afterCompletion := map[string]interface{}{
+				"type": link.AfterCompletion.Type,
+			}
+			if link.AfterCompletion.HostedConfirmation != nil {
+				afterCompletion["hosted_confirmation_custom_message"] = link.AfterCompletion.HostedConfirmation.CustomMessage
+			}
+			if link.AfterCompletion.Redirect != nil {
+				afterCompletion["redirect_url"] = link.AfterCompletion.Redirect.URL
+			}
+			return d.Set("after_completion", []map[string]interface{}{afterCompletion})
+		}(),
+		d.Set("allow_promotion_codes", link.AllowPromotionCodes),
+		d.Set("billing_address_collection", link.BillingAddressCollection),
+		d.Set("currency", link.Currency),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(link.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("url", link.URL),
+	)
+}
+
+func resourceStripePaymentLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &paymentLinkParams{
+		Active:                   stripe.Bool(ExtractBool(d, "active")),
+		AllowPromotionCodes:      stripe.Bool(ExtractBool(d, "allow_promotion_codes")),
+		BillingAddressCollection: stripe.String(ExtractString(d, "billing_address_collection")),
+	}
+
+	for _, v := range ToSlice(d.Get("line_items")) {
+		item := ToMap(v)
+		params.LineItems = append(params.LineItems, &paymentLinkLineItemParams{
+			Price:    stripe.String(ToString(item["price"])),
+			Quantity: stripe.Int64(ToInt64(item["quantity"])),
+		})
+	}
+	if afterCompletion, set := d.GetOk("after_completion"); set {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(afterCompletion)
+	}
+	if currency, set := d.GetOk("currency"); set {
+		params.Currency = stripe.String(ToString(currency))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	link := &paymentLink{}
+	err := rawCall(m, http.MethodPost, "/v1/payment_links", params, link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(link.ID)
+	return resourceStripePaymentLinkRead(ctx, d, m)
+}
+
+func resourceStripePaymentLinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &paymentLinkParams{}
+
+	if !d.HasChanges("active", "after_completion", "allow_promotion_codes", "billing_address_collection", "metadata") {
+		return resourceStripePaymentLinkRead(ctx, d, m)
+	}
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(ExtractBool(d, "active"))
+	}
+	if d.HasChange("after_completion") {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(d.Get("after_completion"))
+	}
+	if d.HasChange("allow_promotion_codes") {
+		params.AllowPromotionCodes = stripe.Bool(ExtractBool(d, "allow_promotion_codes"))
+	}
+	if d.HasChange("billing_address_collection") {
+		params.BillingAddressCollection = stripe.String(ExtractString(d, "billing_address_collection"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	path := stripe.FormatURLPath("/v1/payment_links/%s", d.Id())
+	err := rawCall(m, http.MethodPost, path, params, &paymentLink{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripePaymentLinkRead(ctx, d, m)
+}
+
+// resourceStripePaymentLinkDelete deactivates the payment link, Stripe doesn't delete them.
+func resourceStripePaymentLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &paymentLinkParams{
+		Active: stripe.Bool(false),
+	}
+	params.Context = ctx
+	path := stripe.FormatURLPath("/v1/payment_links/%s", d.Id())
+	err := rawCall(m, http.MethodPost, path, params, &paymentLink{})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandPaymentLinkAfterCompletion(value interface{}) *paymentLinkAfterCompletionParams {
+	afterCompletion := ToMap(ToSlice(value)[0])
+	params := &paymentLinkAfterCompletionParams{
+		Type: stripe.String(ToString(afterCompletion["type"])),
+	}
+	switch ToString(afterCompletion["type"]) {
+	case "hosted_confirmation":
+		if message := ToString(afterCompletion["hosted_confirmation_custom_message"]); message != "" {
+			params.HostedConfirmation = &paymentLinkHostedConfirmationParams{CustomMessage: stripe.String(message)}
+		}
+	case "redirect":
+		params.Redirect = &paymentLinkRedirectParams{URL: stripe.String(ToString(afterCompletion["redirect_url"]))}
+	}
+	return params
+}
@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestResourceStripePaymentLink_deactivate checks that destroying a payment link deactivates it,
+// Stripe has no endpoint to delete one.
+func TestResourceStripePaymentLink_deactivate(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.Form))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+  "id": "plink_123", "object": "payment_link", "active": true, "currency": "usd",
+  "billing_address_collection": "auto", "url": "https://buy.stripe.com/test_123",
+  "after_completion": {"type": "redirect", "redirect": {"url": "https://example.com/thanks"}},
+  "line_items": {"object": "list", "data": [{"id": "li_123", "price": {"id": "price_123"}, "quantity": 2}]}
+}`)
+	})
+
+	config := &Config{API: api}
+	d := schema.TestResourceDataRaw(t, resourceStripePaymentLink().Schema, map[string]interface{}{
+		"line_items":       []interface{}{map[string]interface{}{"price": "price_123", "quantity": 2}},
+		"after_completion": []interface{}{map[string]interface{}{"type": "redirect", "redirect_url": "https://example.com/thanks"}},
+		"idempotency_key":  "test",
+	})
+	if diags := resourceStripePaymentLinkCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if url := ExtractString(d, "url"); url != "https://buy.stripe.com/test_123" {
+		t.Errorf("expected the url of the payment link, got %q", url)
+	}
+	if diags := resourceStripePaymentLinkDelete(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the payment link removed from the state, got ID %q", d.Id())
+	}
+
+	expected := []string{
+		"POST /v1/payment_links map[active:[true] after_completion[redirect][url]:[https://example.com/thanks] " +
+			"after_completion[type]:[redirect] allow_promotion_codes:[false] billing_address_collection:[auto] " +
+			"line_items[0][price]:[price_123] line_items[0][quantity]:[2]]",
+		"GET /v1/payment_links/plink_123 map[expand[0]:[line_items]]",
+		"POST /v1/payment_links/plink_123 map[active:[false]]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,344 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripePaymentMethod() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePaymentMethodRead,
+		CreateContext: resourceStripePaymentMethodCreate,
+		UpdateContext: resourceStripePaymentMethodUpdate,
+		DeleteContext: resourceStripePaymentMethodDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The type of the PaymentMethod, e.g. card. " +
+					"The matching nested block is required to provide the payment method details.",
+			},
+			"card": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Card details, either a token or the raw card fields like a Stripe test card number.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "A token, like the ones returned by Stripe.js or the test token tok_visa.",
+						},
+						"number": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Sensitive:   true,
+							Description: "The card number, as a string without any separators.",
+						},
+						"exp_month": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Two-digit number representing the card's expiration month.",
+						},
+						"exp_year": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Four-digit number representing the card's expiration year.",
+						},
+						"cvc": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Sensitive:   true,
+							Description: "The card's CVC. It is highly recommended to always include this value.",
+						},
+					},
+				},
+			},
+			"billing_details": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Billing information associated with the PaymentMethod " +
+					"that may be used or required by particular types of payment methods.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Full name.",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Email address.",
+						},
+						"phone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Billing phone number (including extension).",
+						},
+						"address": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Address map with fields related to the address: line1, line2, city, state, " +
+								"postal_code and country",
+						},
+					},
+				},
+			},
+			"customer": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The ID of the customer the PaymentMethod is attached to. " +
+					"Changing it detaches the PaymentMethod from the previous customer.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+		},
+	}
+}
+
+func resourceStripePaymentMethodRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	paymentMethod, err := c.PaymentMethods.Get(d.Id(), &stripe.PaymentMethodParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("type", paymentMethod.Type),
+		func() error {
+			billingDetails := paymentMethod.BillingDetails
+			if billingDetails == nil {
+				return d.Set("billing_details", nil)
+			}
+			billingDetailsMap := make(map[string]interface{})
+			if billingDetails.Name != "" {
+				billingDetailsMap["name"] = billingDetails.Name
+			}
+			if billingDetails.Email != "" {
+				billingDetailsMap["email"] = billingDetails.Email
+			}
+			if billingDetails.Phone != "" {
+				billingDetailsMap["phone"] = billingDetails.Phone
+			}
+			if address := billingDetails.Address; address != nil {
+				addressMap := make(map[string]interface{})
+				if address.Line1 != "" {
+					addressMap["line1"] = address.Line1
+				}
+				if address.Line2 != "" {
+					addressMap["line2"] = address.Line2
+				}
+				if address.City != "" {
+					addressMap["city"] = address.City
+				}
+				if address.State != "" {
+					addressMap["state"] = address.State
+				}
+				if address.PostalCode != "" {
+					addressMap["postal_code"] = address.PostalCode
+				}
+				if address.Country != "" {
+					addressMap["country"] = address.Country
+				}
+				if len(addressMap) > 0 {
+					billingDetailsMap["address"] = addressMap
+				}
+			}
+
+			// Stripe always returns the billing details, keep the block empty unless something is set
+			if len(billingDetailsMap) == 0 {
+				return d.Set("billing_details", nil)
+			}
+			return d.Set("billing_details", []interface{}{billingDetailsMap})
+		}(),
+		d.Set("customer", func() string {
+			if paymentMethod.Customer != nil {
+				return paymentMethod.Customer.ID
+			}
+			return ""
+		}()),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(paymentMethod.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
+
+func resourceStripePaymentMethodCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PaymentMethodParams{
+		Type: stripe.String(ExtractString(d, "type")),
+	}
+
+	if card, set := d.GetOk("card"); set {
+		params.Card = &stripe.PaymentMethodCardParams{}
+		for k, v := range ToMap(card) {
+			switch k {
+			case "token":
+				if token := ToString(v); token != "" {
+					params.Card.Token = stripe.String(token)
+				}
+			case "number":
+				if number := ToString(v); number != "" {
+					params.Card.Number = stripe.String(number)
+				}
+			case "exp_month":
+				if expMonth := ToInt(v); expMonth != 0 {
+					params.Card.ExpMonth = stripe.String(strconv.Itoa(expMonth))
+				}
+			case "exp_year":
+				if expYear := ToInt(v); expYear != 0 {
+					params.Card.ExpYear = stripe.String(strconv.Itoa(expYear))
+				}
+			case "cvc":
+				if cvc := ToString(v); cvc != "" {
+					params.Card.CVC = stripe.String(cvc)
+				}
+			}
+		}
+	}
+	if billingDetails, set := d.GetOk("billing_details"); set {
+		params.BillingDetails = expandPaymentMethodBillingDetails(billingDetails)
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	paymentMethod, err := c.PaymentMethods.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(paymentMethod.ID)
+
+	if customer, set := d.GetOk("customer"); set {
+		_, err = c.PaymentMethods.Attach(paymentMethod.ID, &stripe.PaymentMethodAttachParams{
+			Params:   stripe.Params{Context: ctx},
+			Customer: stripe.String(ToString(customer)),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripePaymentMethodRead(ctx, d, m)
+}
+
+func resourceStripePaymentMethodUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	if d.HasChange("customer") {
+		oldCustomer, newCustomer := d.GetChange("customer")
+		if ToString(oldCustomer) != "" {
+			_, err := c.PaymentMethods.Detach(d.Id(), &stripe.PaymentMethodDetachParams{Params: stripe.Params{Context: ctx}})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if ToString(newCustomer) != "" {
+			_, err := c.PaymentMethods.Attach(d.Id(), &stripe.PaymentMethodAttachParams{
+				Params:   stripe.Params{Context: ctx},
+				Customer: stripe.String(ToString(newCustomer)),
+			})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.HasChanges("billing_details", "metadata") {
+		params := &stripe.PaymentMethodParams{}
+
+		if d.HasChange("billing_details") {
+			params.BillingDetails = expandPaymentMethodBillingDetails(d.Get("billing_details"))
+		}
+		if d.HasChange("metadata") {
+			m.(*Config).expandMetadata(d, "metadata", &params.Params)
+		}
+
+		params.Context = ctx
+		_, err := c.PaymentMethods.Update(d.Id(), params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripePaymentMethodRead(ctx, d, m)
+}
+
+func resourceStripePaymentMethodDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	if ExtractString(d, "customer") == "" {
+		log.Println("[WARN] Stripe SDK doesn't support Payment Method deletion through API!")
+		d.SetId("")
+		return nil
+	}
+
+	_, err := c.PaymentMethods.Detach(d.Id(), &stripe.PaymentMethodDetachParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandPaymentMethodBillingDetails always sends every field so that removed values are cleared in Stripe.
+func expandPaymentMethodBillingDetails(value interface{}) *stripe.BillingDetailsParams {
+	billingDetailsMap := ToMap(value)
+	billingDetails := &stripe.BillingDetailsParams{
+		Name:    stripe.String(ToString(billingDetailsMap["name"])),
+		Email:   stripe.String(ToString(billingDetailsMap["email"])),
+		Phone:   stripe.String(ToString(billingDetailsMap["phone"])),
+		Address: &stripe.AddressParams{},
+	}
+	for k, v := range ToMap(billingDetailsMap["address"]) {
+		value := stripe.String(ToString(v))
+		switch k {
+		case "line1":
+			billingDetails.Address.Line1 = value
+		case "line2":
+			billingDetails.Address.Line2 = value
+		case "city":
+			billingDetails.Address.City = value
+		case "state":
+			billingDetails.Address.State = value
+		case "postal_code":
+			billingDetails.Address.PostalCode = value
+		case "country":
+			billingDetails.Address.Country = value
+		}
+	}
+	return billingDetails
+}
@@ -0,0 +1,188 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripePayout() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePayoutRead,
+		CreateContext: resourceStripePayoutCreate,
+		UpdateContext: resourceStripePayoutUpdate,
+		DeleteContext: resourceStripePayoutDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"amount": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "A positive integer in cents representing how much to payout.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
+			},
+			"method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(stripe.PayoutMethodStandard),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.PayoutMethodStandard),
+					string(stripe.PayoutMethodInstant),
+				}, false),
+				Description: "The method used to send this payout, either standard or instant. Defaults to standard.",
+			},
+			"destination": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The ID of a bank account or a card to send the payout to. " +
+					"Defaults to the default external account for the currency.",
+			},
+			"statement_descriptor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(0, 22),
+				Description: "A string to be displayed on the recipient’s bank or card statement. " +
+					"This may be at most 22 characters.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Current status of the payout, one of paid, pending, in_transit, " +
+					"canceled, or failed.",
+			},
+			"arrival_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date the payout is expected to arrive in the bank, in RFC3339 format.",
+			},
+		},
+	}
+}
+
+func resourceStripePayoutRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	payout, err := c.Payouts.Get(d.Id(), &stripe.PayoutParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("amount", payout.Amount),
+		d.Set("currency", payout.Currency),
+		d.Set("method", payout.Method),
+		func() error {
+			if payout.Destination != nil {
+				return d.Set("destination", payout.Destination.ID)
+			}
+			return nil
+		}(),
+		d.Set("statement_descriptor", payout.StatementDescriptor),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(payout.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", payout.Status),
+		d.Set("arrival_date", ToRFC3339(payout.ArrivalDate)),
+	)
+}
+
+func resourceStripePayoutCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PayoutParams{
+		Amount:   stripe.Int64(ExtractInt64(d, "amount")),
+		Currency: stripe.String(ExtractString(d, "currency")),
+		Method:   stripe.String(ExtractString(d, "method")),
+	}
+
+	if destination, set := d.GetOk("destination"); set {
+		params.Destination = stripe.String(ToString(destination))
+	}
+	if statementDescriptor, set := d.GetOk("statement_descriptor"); set {
+		params.StatementDescriptor = stripe.String(ToString(statementDescriptor))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	payout, err := c.Payouts.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(payout.ID)
+	return resourceStripePayoutRead(ctx, d, m)
+}
+
+func resourceStripePayoutUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PayoutParams{}
+
+	// the metadata is the only thing Stripe allows to change on a payout
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+		params.Context = ctx
+
+		_, err := c.Payouts.Update(d.Id(), params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripePayoutRead(ctx, d, m)
+}
+
+func resourceStripePayoutDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	// the status in the state may be outdated, payouts move on without Terraform
+	payout, err := c.Payouts.Get(d.Id(), &stripe.PayoutParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if payout.Status != stripe.PayoutStatusPending {
+		log.Printf("[WARN] Payout %s is %s and can't be canceled, removing it from the state",
+			d.Id(), payout.Status)
+		d.SetId("")
+		return nil
+	}
+
+	_, err = c.Payouts.Cancel(d.Id(), &stripe.PayoutParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,371 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripePlan() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePlanRead,
+		CreateContext: resourceStripePlanCreate,
+		UpdateContext: resourceStripePlanUpdate,
+		DeleteContext: resourceStripePlanDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"amount": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Description: "A positive integer in cents (or 0 for a free plan) representing how much to charge " +
+					"on a recurring basis. Not used for plans with billing_scheme=tiered.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
+			},
+			"interval": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Specifies billing frequency. Either day, week, month or year.",
+			},
+			"interval_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  1,
+				Description: "The number of intervals between subscription billings. " +
+					"For example, interval=month and interval_count=3 bills every 3 months. " +
+					"Maximum of one year interval allowed (1 year, 12 months, or 52 weeks).",
+			},
+			"product": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"product_data"},
+				Description:   "The ID of the product whose pricing this plan determines.",
+			},
+			"product_data": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"product"},
+				Description:   "Creates a new product inline and attaches the plan to it.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The product’s name, meant to be displayable to the customer.",
+						},
+						"statement_descriptor": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Description: "An arbitrary string to be displayed on your customer’s credit card " +
+								"or bank statement. This may be up to 22 characters.",
+						},
+						"unit_label": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Description: "A label that represents units of this product in Stripe and on customers’ " +
+								"receipts and invoices.",
+						},
+					},
+				},
+			},
+			"nickname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A brief description of the plan, hidden from customers.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the plan is currently available for new subscriptions. Defaults to true.",
+			},
+			"usage_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "licensed",
+				Description: "Configures how the quantity per period should be determined. " +
+					"Can be either metered or licensed. Defaults to licensed.",
+			},
+			"aggregate_usage": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "Specifies a usage aggregation strategy for plans of usage_type=metered. " +
+					"One of sum, last_during_period, last_ever or max.",
+			},
+			"billing_scheme": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "per_unit",
+				Description: "Describes how to compute the price per period. Either per_unit or tiered. " +
+					"per_unit indicates that the fixed amount will be charged per unit in quantity, " +
+					"tiered indicates that the unit pricing will be computed using a tiering strategy " +
+					"as defined using the tiers and tiers_mode attributes.",
+			},
+			"trial_period_days": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "Default number of trial days when subscribing a customer to this plan " +
+					"using trial_from_plan=true.",
+			},
+			"tiers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Description: "Each element represents a pricing tier. " +
+					"This parameter requires billing_scheme to be set to tiered.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"up_to": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+							Description: "Specifies the upper bound of this tier. " +
+								"The lower bound of a tier is the upper bound of the previous tier adding one. " +
+								"Use -1 to define a fallback tier.",
+						},
+						"flat_amount": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+							Description: "The flat billing amount for an entire tier, " +
+								"regardless of the number of units in the tier.",
+						},
+						"unit_amount": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+							Description: "The per unit billing amount for each individual unit " +
+								"for which this tier applies.",
+						},
+					},
+				},
+			},
+			"tiers_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "Defines if the tiering price should be graduated or volume based. " +
+					"In volume-based tiering, the maximum quantity within a period determines the per unit price, " +
+					"in graduated tiering pricing can successively change as the quantity grows.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+		},
+	}
+}
+
+func resourceStripePlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PlanParams{}
+	if m.(*Config).expandOnRead(d, "tiers") {
+		params.AddExpand("tiers")
+	}
+	params.Context = ctx
+	plan, err := c.Plans.Get(d.Id(), params)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("amount", plan.Amount),
+		d.Set("currency", plan.Currency),
+		d.Set("interval", plan.Interval),
+		d.Set("interval_count", plan.IntervalCount),
+		func() error {
+			if plan.Product != nil {
+				return d.Set("product", plan.Product.ID)
+			}
+			return nil
+		}(),
+		d.Set("nickname", plan.Nickname),
+		d.Set("active", plan.Active),
+		d.Set("usage_type", plan.UsageType),
+		d.Set("aggregate_usage", plan.AggregateUsage),
+		d.Set("billing_scheme", plan.BillingScheme),
+		d.Set("trial_period_days", plan.TrialPeriodDays),
+		func() error {
+			// an empty list records that the tiers were read, see expandOnRead
+			var tiers []map[string]interface{}
+			for _, tier := range plan.Tiers {
+				tiers = append(tiers, map[string]interface{}{
+					"up_to": func() int64 {
+						// update the value to reflect the Terraform input
+						if tier.UpTo == 0 {
+							return -1
+						}
+						return tier.UpTo
+					}(),
+					"flat_amount": tier.FlatAmount,
+					"unit_amount": tier.UnitAmount,
+				})
+			}
+			return d.Set("tiers", tiers)
+		}(),
+		d.Set("tiers_mode", plan.TiersMode),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(plan.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
+
+func resourceStripePlanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PlanParams{
+		Currency:      stripe.String(ExtractString(d, "currency")),
+		Interval:      stripe.String(ExtractString(d, "interval")),
+		IntervalCount: stripe.Int64(ExtractInt64(d, "interval_count")),
+		Active:        stripe.Bool(ExtractBool(d, "active")),
+	}
+
+	if amount, set := d.GetOk("amount"); set {
+		params.Amount = stripe.Int64(ToInt64(amount))
+	}
+	if product, set := d.GetOk("product"); set {
+		params.ProductID = stripe.String(ToString(product))
+	}
+	if productData, set := d.GetOk("product_data"); set {
+		params.Product = &stripe.PlanProductParams{}
+		for k, v := range ToMap(productData) {
+			switch {
+			case k == "name":
+				params.Product.Name = stripe.String(ToString(v))
+			case k == "statement_descriptor" && ToString(v) != "":
+				params.Product.StatementDescriptor = stripe.String(ToString(v))
+			case k == "unit_label" && ToString(v) != "":
+				params.Product.UnitLabel = stripe.String(ToString(v))
+			}
+		}
+	}
+	if params.ProductID == nil && params.Product == nil {
+		return diag.Errorf("either product or product_data has to be set")
+	}
+	if nickname, set := d.GetOk("nickname"); set {
+		params.Nickname = stripe.String(ToString(nickname))
+	}
+	if usageType, set := d.GetOk("usage_type"); set {
+		params.UsageType = stripe.String(ToString(usageType))
+	}
+	if aggregateUsage, set := d.GetOk("aggregate_usage"); set {
+		params.AggregateUsage = stripe.String(ToString(aggregateUsage))
+	}
+	if billingScheme, set := d.GetOk("billing_scheme"); set {
+		params.BillingScheme = stripe.String(ToString(billingScheme))
+	}
+	if trialPeriodDays, set := d.GetOk("trial_period_days"); set {
+		params.TrialPeriodDays = stripe.Int64(ToInt64(trialPeriodDays))
+	}
+	if tiers, set := d.GetOk("tiers"); set {
+		for _, t := range ToSlice(tiers) {
+			planTier := &stripe.PlanTierParams{}
+			for k, v := range ToMap(t) {
+				switch k {
+				case "up_to":
+					upTo := ToInt64(v)
+					if upTo < 0 {
+						planTier.UpToInf = stripe.Bool(true)
+					} else {
+						planTier.UpTo = stripe.Int64(upTo)
+					}
+				case "flat_amount":
+					if amount := ToInt64(v); amount != 0 {
+						planTier.FlatAmount = stripe.Int64(amount)
+					}
+				case "unit_amount":
+					if amount := ToInt64(v); amount != 0 {
+						planTier.UnitAmount = stripe.Int64(amount)
+					}
+				}
+			}
+			if planTier.FlatAmount == nil && planTier.UnitAmount == nil {
+				planTier.UnitAmount = stripe.Int64(0)
+			}
+			params.Tiers = append(params.Tiers, planTier)
+		}
+	}
+	if tiersMode, set := d.GetOk("tiers_mode"); set {
+		params.TiersMode = stripe.String(ToString(tiersMode))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	plan, err := c.Plans.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(plan.ID)
+	return resourceStripePlanRead(ctx, d, m)
+}
+
+func resourceStripePlanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.PlanParams{}
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(ExtractBool(d, "active"))
+	}
+	if d.HasChange("nickname") {
+		params.Nickname = stripe.String(ExtractString(d, "nickname"))
+	}
+	if d.HasChange("trial_period_days") {
+		params.TrialPeriodDays = stripe.Int64(ExtractInt64(d, "trial_period_days"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Plans.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripePlanRead(ctx, d, m)
+}
+
+func resourceStripePlanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	_, err := c.Plans.Del(d.Id(), &stripe.PlanParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,57 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestResourceStripePlanRead_importMinimalReads imports plans with minimal_reads, the first read expands the tiers
+// which aren't in the state yet. Afterwards a plan without tiers is refreshed without the expand.
+func TestResourceStripePlanRead_importMinimalReads(t *testing.T) {
+	cases := []struct {
+		name     string
+		tiers    string
+		count    string
+		expanded []bool
+	}{
+		{"tiered", `[{"up_to": 10, "unit_amount": 100}, {"up_to": null, "unit_amount": 80}]`, "2", []bool{true, true}},
+		{"per unit", `null`, "0", []bool{true, false}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var expanded []bool
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				expanded = append(expanded, strings.Contains(r.URL.RawQuery, "tiers"))
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id": "plan_123", "object": "plan", "currency": "usd", "interval": "month",
+					"interval_count": 1, "product": "prod_123", "tiers": %s}`, tc.tiers)
+			})
+			config := &Config{API: api, MinimalReads: true}
+			r := resourceStripePlan()
+
+			d := r.Data(nil)
+			d.SetId("plan_123")
+			imported, err := r.Importer.StateContext(context.Background(), d, config)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			state, diags := r.RefreshWithoutUpgrade(context.Background(), imported[0].State(), config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if count := state.Attributes["tiers.#"]; count != tc.count {
+				t.Errorf("expected %s tiers, got %q", tc.count, count)
+			}
+
+			if _, diags := r.RefreshWithoutUpgrade(context.Background(), state, config); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if fmt.Sprint(expanded) != fmt.Sprint(tc.expanded) {
+				t.Errorf("expected the reads to expand the tiers %v, got %v", tc.expanded, expanded)
+			}
+		})
+	}
+}
@@ -2,12 +2,10 @@ package stripe
 
 import (
 	"context"
-	"errors"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripePrice() *schema.Resource {
@@ -22,11 +20,13 @@ func resourceStripePrice() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"idempotency_key": idempotencyKeySchema(),
 			"currency": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Three-letter ISO currency code, in lowercase.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
 			},
 			"product": {
 				Type:        schema.TypeString,
@@ -238,20 +238,17 @@ func resourceStripePrice() *schema.Resource {
 	}
 }
 
-func resourceStripePriceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 	params := &stripe.PriceParams{}
-	params.AddExpand("tiers")
+	if m.(*Config).expandOnRead(d, "tiers") {
+		params.AddExpand("tiers")
+	}
+	params.Context = ctx
 	price, err := c.Prices.Get(d.Id(), params)
 	if err != nil {
-		var stripeErr *stripe.Error
-		if errors.As(err, &stripeErr) {
-			if stripeErr.Type == stripe.ErrorTypeInvalidRequest &&
-				stripeErr.HTTPStatusCode == 404 {
-				// Price got deleted in Stripe
-				d.SetId("")
-				return nil
-			}
+		if handleNotFound(err, d) {
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -281,33 +278,31 @@ func resourceStripePriceRead(_ context.Context, d *schema.ResourceData, m interf
 			return nil
 		}(),
 		func() error {
-			if len(price.Tiers) > 0 {
-				var tiers []map[string]interface{}
-				for _, tier := range price.Tiers {
-					t := map[string]interface{}{
-						"up_to": func() int64 {
-							// update the value to reflect the Terraform input
-							if tier.UpTo == 0 {
-								return -1
-							}
-							return tier.UpTo
-						}(),
-						"flat_amount":         tier.FlatAmount,
-						"flat_amount_decimal": tier.FlatAmountDecimal,
-						"unit_amount":         tier.UnitAmount,
-						"unit_amount_decimal": tier.UnitAmountDecimal,
-					}
-					if t["flat_amount"] != 0 && t["flat_amount_decimal"] != 0 {
-						t["flat_amount"] = 0
-					}
-					if t["unit_amount"] != 0 && t["unit_amount_decimal"] != 0 {
-						t["unit_amount"] = 0
-					}
-					tiers = append(tiers, t)
+			// an empty list records that the tiers were read, see expandOnRead
+			var tiers []map[string]interface{}
+			for _, tier := range price.Tiers {
+				t := map[string]interface{}{
+					"up_to": func() int64 {
+						// update the value to reflect the Terraform input
+						if tier.UpTo == 0 {
+							return -1
+						}
+						return tier.UpTo
+					}(),
+					"flat_amount":         tier.FlatAmount,
+					"flat_amount_decimal": tier.FlatAmountDecimal,
+					"unit_amount":         tier.UnitAmount,
+					"unit_amount_decimal": tier.UnitAmountDecimal,
+				}
+				if t["flat_amount"] != 0 && t["flat_amount_decimal"] != 0 {
+					t["flat_amount"] = 0
 				}
-				return d.Set("tiers", tiers)
+				if t["unit_amount"] != 0 && t["unit_amount_decimal"] != 0 {
+					t["unit_amount"] = 0
+				}
+				tiers = append(tiers, t)
 			}
-			return nil
+			return d.Set("tiers", tiers)
 		}(),
 		d.Set("tiers_mode", price.TiersMode),
 		d.Set("billing_scheme", price.BillingScheme),
@@ -325,12 +320,12 @@ func resourceStripePriceRead(_ context.Context, d *schema.ResourceData, m interf
 			return nil
 		}(),
 		d.Set("type", price.Type),
-		d.Set("metadata", price.Metadata),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(price.Metadata, ExtractMap(d, "metadata"))),
 	)
 }
 
 func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.PriceParams{
 		Product:  stripe.String(ExtractString(d, "product")),
 		Currency: stripe.String(ExtractString(d, "currency")),
@@ -435,12 +430,10 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 			}
 		}
 	}
-	if meta, set := d.GetOk("metadata"); set {
-		for k, v := range ToMap(meta) {
-			params.AddMetadata(k, ToString(v))
-		}
-	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
 
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
 	price, err := c.Prices.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -461,7 +454,7 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.PriceParams{}
 
 	if d.HasChange("active") {
@@ -480,13 +473,10 @@ func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		params.TaxBehavior = stripe.String(ExtractString(d, "tax_behaviour"))
 	}
 	if d.HasChange("metadata") {
-		params.Metadata = nil
-		metadata := ExtractMap(d, "metadata")
-		for k, v := range metadata {
-			params.AddMetadata(k, ToString(v))
-		}
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
 	}
 
+	params.Context = ctx
 	_, err := c.Prices.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -495,10 +485,11 @@ func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	return resourceStripePriceRead(ctx, d, m)
 }
 
-func resourceStripePriceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripePriceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 	params := &stripe.PriceParams{}
 	params.Active = stripe.Bool(false)
+	params.Context = ctx
 	_, err := c.Prices.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -2,12 +2,10 @@ package stripe
 
 import (
 	"context"
-	"errors"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripeProduct() *schema.Resource {
@@ -22,6 +20,7 @@ func resourceStripeProduct() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"idempotency_key": idempotencyKeySchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -93,18 +92,12 @@ func resourceStripeProduct() *schema.Resource {
 	}
 }
 
-func resourceStripeProductRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
-	product, err := c.Products.Get(d.Id(), nil)
+func resourceStripeProductRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	product, err := c.Products.Get(d.Id(), &stripe.ProductParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
-		var stripeErr *stripe.Error
-		if errors.As(err, &stripeErr) {
-			if stripeErr.Type == stripe.ErrorTypeInvalidRequest &&
-				stripeErr.HTTPStatusCode == 404 {
-				// Product got deleted in Stripe
-				d.SetId("")
-				return nil
-			}
+		if handleNotFound(err, d) {
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -129,12 +122,12 @@ func resourceStripeProductRead(_ context.Context, d *schema.ResourceData, m inte
 		d.Set("statement_descriptor", product.StatementDescriptor),
 		d.Set("unit_label", product.UnitLabel),
 		d.Set("url", product.URL),
-		d.Set("metadata", product.Metadata),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(product.Metadata, ExtractMap(d, "metadata"))),
 	)
 }
 
 func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.ProductParams{
 		Name: stripe.String(ExtractString(d, "name")),
 	}
@@ -175,12 +168,10 @@ func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m
 	if url, set := d.GetOk("url"); set {
 		params.URL = stripe.String(ToString(url))
 	}
-	if meta, set := d.GetOk("metadata"); set {
-		for k, v := range ToMap(meta) {
-			params.AddMetadata(k, ToString(v))
-		}
-	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
 
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
 	product, err := c.Products.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -196,7 +187,7 @@ func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.ProductParams{}
 	if d.HasChange("name") {
 		params.Name = stripe.String(ExtractString(d, "name"))
@@ -239,13 +230,10 @@ func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m
 		params.URL = stripe.String(ExtractString(d, "url"))
 	}
 	if d.HasChange("metadata") {
-		params.Metadata = nil
-		metadata := ExtractMap(d, "metadata")
-		for k, v := range metadata {
-			params.AddMetadata(k, ToString(v))
-		}
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
 	}
 
+	params.Context = ctx
 	_, err := c.Products.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -254,10 +242,11 @@ func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m
 	return resourceStripeProductRead(ctx, d, m)
 }
 
-func resourceStripeProductDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeProductDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 	params := &stripe.ProductParams{}
 	params.Active = stripe.Bool(false)
+	params.Context = ctx
 	_, err := c.Products.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
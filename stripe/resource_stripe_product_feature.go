@@ -0,0 +1,142 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// stripe-go v72 predates entitlements, the product features are sent through the backend directly
+type productFeatureParams struct {
+	stripe.Params      `form:"*"`
+	EntitlementFeature *string `form:"entitlement_feature"`
+}
+
+type productFeature struct {
+	stripe.APIResource
+	ID                 string               `json:"id"`
+	EntitlementFeature *entitlementsFeature `json:"entitlement_feature"`
+}
+
+func resourceStripeProductFeature() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeProductFeatureRead,
+		CreateContext: resourceStripeProductFeatureCreate,
+		DeleteContext: resourceStripeProductFeatureDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStripeProductFeatureImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the product and the ID of the product feature, separated by a colon.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"product": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the product the feature is attached to.",
+			},
+			"entitlement_feature": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the entitlements feature attached to the product.",
+			},
+		},
+	}
+}
+
+// resourceStripeProductFeatureImport expects the <product_id>:<product_feature_id> format,
+// the product is part of every request made for the product feature.
+func resourceStripeProductFeatureImport(ctx context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	product, _, err := parseProductFeatureID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("product", product); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceStripeProductFeatureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	product, id, err := parseProductFeatureID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	feature := &productFeature{}
+	path := stripe.FormatURLPath("/v1/products/%s/features/%s", product, id)
+	err = rawCall(m, http.MethodGet, path, &stripe.Params{Context: ctx}, feature)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("product", product),
+		func() error {
+			if feature.EntitlementFeature != nil {
+				return d.Set("entitlement_feature", feature.EntitlementFeature.ID)
+			}
+			return nil
+		}(),
+	)
+}
+
+func resourceStripeProductFeatureCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	product := ExtractString(d, "product")
+	params := &productFeatureParams{
+		EntitlementFeature: stripe.String(ExtractString(d, "entitlement_feature")),
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	feature := &productFeature{}
+	path := stripe.FormatURLPath("/v1/products/%s/features", product)
+	err := rawCall(m, http.MethodPost, path, params, feature)
+	if err != nil {
+		return diagFromStripeErr(err)
+	}
+
+	d.SetId(product + ":" + feature.ID)
+	return resourceStripeProductFeatureRead(ctx, d, m)
+}
+
+func resourceStripeProductFeatureDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	product, id, err := parseProductFeatureID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path := stripe.FormatURLPath("/v1/products/%s/features/%s", product, id)
+	err = rawCall(m, http.MethodDelete, path, &stripe.Params{Context: ctx}, &productFeature{})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func parseProductFeatureID(id string) (product, feature string, err error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected ID %q, expected <product_id>:<product_feature_id>", id)
+	}
+	return parts[0], parts[1], nil
+}
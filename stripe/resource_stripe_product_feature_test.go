@@ -0,0 +1,64 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestResourceStripeProductFeature_id checks that the ID combining the product and the product feature
+// is used for the requests after the create and split again by the import.
+func TestResourceStripeProductFeature_id(t *testing.T) {
+	var requests []string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "prodft_123", "object": "product_feature",
+  "entitlement_feature": {"id": "feat_123", "object": "entitlements.feature", "lookup_key": "seats"}}`)
+	})
+
+	config := &Config{API: api}
+	d := schema.TestResourceDataRaw(t, resourceStripeProductFeature().Schema, map[string]interface{}{
+		"product":             "prod_123",
+		"entitlement_feature": "feat_123",
+		"idempotency_key":     "test",
+	})
+	if diags := resourceStripeProductFeatureCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "prod_123:prodft_123" {
+		t.Errorf("expected the ID prod_123:prodft_123, got %q", d.Id())
+	}
+	if diags := resourceStripeProductFeatureDelete(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		"POST /v1/products/prod_123/features",
+		"GET /v1/products/prod_123/features/prodft_123",
+		"DELETE /v1/products/prod_123/features/prodft_123",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+
+	for _, id := range []string{"prodft_123", "prod_123:", ":prodft_123"} {
+		d := resourceStripeProductFeature().Data(nil)
+		d.SetId(id)
+		if _, err := resourceStripeProductFeatureImport(context.Background(), d, config); err == nil {
+			t.Errorf("expected the import of %q to fail", id)
+		}
+	}
+
+	d = resourceStripeProductFeature().Data(nil)
+	d.SetId("prod_123:prodft_123")
+	if _, err := resourceStripeProductFeatureImport(context.Background(), d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if product := ExtractString(d, "product"); product != "prod_123" {
+		t.Errorf("expected the product prod_123 imported, got %q", product)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripePromotionCode() *schema.Resource {
@@ -23,6 +22,7 @@ func resourceStripePromotionCode() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"idempotency_key": idempotencyKeySchema(),
 			"coupon": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -38,10 +38,11 @@ func resourceStripePromotionCode() *schema.Resource {
 					"If left blank, we will generate one automatically.",
 			},
 			"active": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				Description: "Whether the promotion code is currently active.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether the promotion code is currently active. " +
+					"Changed in place like metadata, to disable a code without recreating it.",
 			},
 			"customer": {
 				Type:     schema.TypeString,
@@ -59,9 +60,10 @@ func resourceStripePromotionCode() *schema.Resource {
 					"then this value cannot be greater than the coupon’s max_redemptions.",
 			},
 			"expires_at": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRFC3339,
 				Description: "The timestamp at which this promotion code will expire. " +
 					"If the coupon has specified a redeems_by, " +
 					"then this value cannot be after the coupon’s redeems_by. Expected format is RFC3339",
@@ -81,15 +83,18 @@ func resourceStripePromotionCode() *schema.Resource {
 								"redeemed for Customers without any successful payments or invoices",
 						},
 						"minimum_amount": {
-							Type:     schema.TypeInt,
-							Required: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							RequiredWith: []string{"restrictions.0.minimum_amount_currency"},
 							Description: "Minimum amount required to redeem this Promotion Code into a Coupon " +
 								"(e.g., a purchase must be $100 or more to work).",
 						},
 						"minimum_amount_currency": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Three-letter ISO code for minimum_amount",
+							Type:         schema.TypeString,
+							Optional:     true,
+							RequiredWith: []string{"restrictions.0.minimum_amount"},
+							ValidateFunc: validateCurrency,
+							Description:  "Three-letter ISO code for minimum_amount, required when minimum_amount is set.",
 						},
 					},
 				},
@@ -106,7 +111,7 @@ func resourceStripePromotionCode() *schema.Resource {
 }
 
 func resourceStripePromotionCodeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.PromotionCodeParams{
 		Coupon: stripe.String(ExtractString(d, "coupon")),
 		Active: stripe.Bool(ExtractBool(d, "active")),
@@ -135,18 +140,20 @@ func resourceStripePromotionCodeCreate(ctx context.Context, d *schema.ResourceDa
 			case "first_time_transaction":
 				params.Restrictions.FirstTimeTransaction = stripe.Bool(ToBool(v))
 			case "minimum_amount":
-				params.Restrictions.MinimumAmount = stripe.Int64(ToInt64(v))
+				if minimumAmount := ToInt64(v); minimumAmount > 0 {
+					params.Restrictions.MinimumAmount = stripe.Int64(minimumAmount)
+				}
 			case "minimum_amount_currency":
-				params.Restrictions.MinimumAmountCurrency = stripe.String(ToString(v))
+				if currency := ToString(v); currency != "" {
+					params.Restrictions.MinimumAmountCurrency = stripe.String(currency)
+				}
 			}
 		}
 	}
-	if meta, set := d.GetOk("metadata"); set {
-		for k, v := range ToMap(meta) {
-			params.AddMetadata(k, ToString(v))
-		}
-	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
 
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
 	promotionCode, err := c.PromotionCodes.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -156,10 +163,13 @@ func resourceStripePromotionCodeCreate(ctx context.Context, d *schema.ResourceDa
 	return resourceStripePromotionCodeRead(ctx, d, m)
 }
 
-func resourceStripePromotionCodeRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
-	promotionCode, err := c.PromotionCodes.Get(d.Id(), nil)
+func resourceStripePromotionCodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	promotionCode, err := c.PromotionCodes.Get(d.Id(), &stripe.PromotionCodeParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -179,7 +189,12 @@ func resourceStripePromotionCodeRead(_ context.Context, d *schema.ResourceData,
 			return nil
 		}(),
 		d.Set("max_redemptions", promotionCode.MaxRedemptions),
-		d.Set("expires_at", time.Unix(promotionCode.ExpiresAt, 0).Format(time.RFC3339)),
+		func() error {
+			if promotionCode.ExpiresAt != 0 {
+				return d.Set("expires_at", time.Unix(promotionCode.ExpiresAt, 0).Format(time.RFC3339))
+			}
+			return nil
+		}(),
 		func() error {
 			if promotionCode.Restrictions != nil {
 				return d.Set("restrictions", []map[string]interface{}{
@@ -192,23 +207,25 @@ func resourceStripePromotionCodeRead(_ context.Context, d *schema.ResourceData,
 			}
 			return nil
 		}(),
-		d.Set("metadata", promotionCode.Metadata),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(promotionCode.Metadata, ExtractMap(d, "metadata"))),
 	)
 }
 
 func resourceStripePromotionCodeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.PromotionCodeParams{}
+
+	if !d.HasChanges("active", "metadata") {
+		return resourceStripePromotionCodeRead(ctx, d, m)
+	}
+
 	if d.HasChange("active") {
 		params.Active = stripe.Bool(ExtractBool(d, "active"))
 	}
 	if d.HasChange("metadata") {
-		params.Metadata = nil
-		metadata := ExtractMap(d, "metadata")
-		for k, v := range metadata {
-			params.AddMetadata(k, ToString(v))
-		}
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
 	}
+	params.Context = ctx
 	_, err := c.PromotionCodes.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -217,7 +234,7 @@ func resourceStripePromotionCodeUpdate(ctx context.Context, d *schema.ResourceDa
 	return resourceStripePromotionCodeRead(ctx, d, m)
 }
 
-func resourceStripePromotionCodeDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+func resourceStripePromotionCodeDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	log.Println("[WARN] Stripe SDK doesn't support Promotion Code deletion through API!")
 	d.SetId("")
 	return nil
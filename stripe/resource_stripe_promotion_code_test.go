@@ -0,0 +1,130 @@
+package stripe
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripePromotionCode_toggleActive(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePromotionCodeConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "active", "true"),
+					testAccCheckStripePromotionCodeActive("stripe_promotion_code.test", &id, true),
+				),
+			},
+			{
+				Config: testAccStripePromotionCodeConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "active", "false"),
+					testAccCheckStripePromotionCodeActive("stripe_promotion_code.test", &id, false),
+				),
+			},
+			{
+				Config: testAccStripePromotionCodeConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "active", "true"),
+					testAccCheckStripePromotionCodeActive("stripe_promotion_code.test", &id, true),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceStripePromotionCode_validateRestrictions(t *testing.T) {
+	cases := []struct {
+		name         string
+		restrictions map[string]interface{}
+		expected     string
+	}{
+		{"first time transaction only", map[string]interface{}{"first_time_transaction": true}, ""},
+		{"minimum amount", map[string]interface{}{
+			"first_time_transaction":  false,
+			"minimum_amount":          100,
+			"minimum_amount_currency": "aud",
+		}, ""},
+		{"minimum amount without currency", map[string]interface{}{
+			"first_time_transaction": false,
+			"minimum_amount":         100,
+		}, "minimum_amount_currency"},
+		{"currency without minimum amount", map[string]interface{}{
+			"first_time_transaction":  false,
+			"minimum_amount_currency": "aud",
+		}, "minimum_amount"},
+		{"unknown currency", map[string]interface{}{
+			"first_time_transaction":  false,
+			"minimum_amount":          100,
+			"minimum_amount_currency": "xyz",
+		}, "ISO 4217"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := resourceStripePromotionCode().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"coupon":       "test",
+				"restrictions": []interface{}{tc.restrictions},
+			}))
+			if tc.expected == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() {
+				t.Fatalf("expected an error mentioning %s", tc.expected)
+			}
+			if summary := diags[0].Summary + diags[0].Detail; !strings.Contains(summary, tc.expected) {
+				t.Errorf("expected an error mentioning %s, got %q", tc.expected, summary)
+			}
+		})
+	}
+}
+
+// testAccCheckStripePromotionCodeActive checks the promotion code Stripe has, and that it's still the one
+// the first step created, toggling active must not replace it.
+func testAccCheckStripePromotionCodeActive(name string, id *string, active bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		if *id == "" {
+			*id = rs.Primary.ID
+		} else if rs.Primary.ID != *id {
+			return fmt.Errorf("promotion code %s was replaced by %s", *id, rs.Primary.ID)
+		}
+
+		c := testAccProvider.Meta().(*Config).API
+		promotionCode, err := c.PromotionCodes.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+		if promotionCode.Active != active {
+			return fmt.Errorf("promotion code %s is active=%t, expected %t", promotionCode.ID, promotionCode.Active, active)
+		}
+		return nil
+	}
+}
+
+func testAccStripePromotionCodeConfig(active bool) string {
+	return fmt.Sprintf(`
+resource "stripe_coupon" "test" {
+  name        = "Promotion code test"
+  percent_off = 10
+  duration    = "once"
+}
+
+resource "stripe_promotion_code" "test" {
+  coupon = stripe_coupon.test.id
+  active = %t
+}
+`, active)
+}
@@ -0,0 +1,305 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeQuote() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeQuoteRead,
+		CreateContext: resourceStripeQuoteCreate,
+		UpdateContext: resourceStripeQuoteUpdate,
+		DeleteContext: resourceStripeQuoteDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer who is being quoted.",
+			},
+			"line_items": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A list of line items the customer is being quoted for.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"price": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the price object.",
+						},
+						"quantity": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "The quantity of the line item.",
+						},
+					},
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description that will be displayed on the quote PDF.",
+			},
+			"expires_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validateRFC3339,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+				Description: "A future date on which the quote will be canceled if in open or draft status. " +
+					"Expected format is RFC3339, defaults to 30 days after the quote is created.",
+			},
+			"collection_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.QuoteCollectionMethodChargeAutomatically),
+					string(stripe.QuoteCollectionMethodSendInvoice),
+				}, false),
+				Description: "Either charge_automatically, or send_invoice. " +
+					"Defaults to charge_automatically.",
+			},
+			"default_tax_rates": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The tax rates that will apply to any line item " +
+					"that does not have tax_rates set.",
+			},
+			"discounts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The discounts applied to the quote.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"coupon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of the coupon to create a new discount for.",
+						},
+						"discount": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of an existing discount on the customer to reuse.",
+						},
+					},
+				},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the quote, one of draft, open, accepted, or canceled.",
+			},
+			"amount_total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total after discounts and taxes are applied.",
+			},
+			"number": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "A unique number that identifies this particular quote. " +
+					"Assigned once the quote is finalized.",
+			},
+		},
+	}
+}
+
+func expandQuoteLineItems(lineItems interface{}) []*stripe.QuoteLineItemParams {
+	var params []*stripe.QuoteLineItemParams
+	for _, item := range ToSlice(lineItems) {
+		lineItem := &stripe.QuoteLineItemParams{}
+		for k, v := range ToMap(item) {
+			switch k {
+			case "price":
+				lineItem.Price = stripe.String(ToString(v))
+			case "quantity":
+				lineItem.Quantity = stripe.Int64(ToInt64(v))
+			}
+		}
+		params = append(params, lineItem)
+	}
+	return params
+}
+
+func expandQuoteDiscounts(discounts interface{}) []*stripe.QuoteDiscountParams {
+	var params []*stripe.QuoteDiscountParams
+	for _, d := range ToSlice(discounts) {
+		discount := &stripe.QuoteDiscountParams{}
+		for k, v := range ToMap(d) {
+			switch k {
+			case "coupon":
+				if coupon := ToString(v); coupon != "" {
+					discount.Coupon = stripe.String(coupon)
+				}
+			case "discount":
+				if id := ToString(v); id != "" {
+					discount.Discount = stripe.String(id)
+				}
+			}
+		}
+		params = append(params, discount)
+	}
+	return params
+}
+
+func resourceStripeQuoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	quote, err := c.Quotes.Get(d.Id(), &stripe.QuoteParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("customer", quote.Customer.ID),
+		d.Set("description", quote.Description),
+		d.Set("expires_at", ToRFC3339(quote.ExpiresAt)),
+		d.Set("collection_method", quote.CollectionMethod),
+		func() error {
+			var taxRates []string
+			for _, taxRate := range quote.DefaultTaxRates {
+				taxRates = append(taxRates, taxRate.ID)
+			}
+			return d.Set("default_tax_rates", taxRates)
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(quote.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", quote.Status),
+		d.Set("amount_total", quote.AmountTotal),
+		d.Set("number", quote.Number),
+	)
+}
+
+func resourceStripeQuoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.QuoteParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+
+	if lineItems, set := d.GetOk("line_items"); set {
+		params.LineItems = expandQuoteLineItems(lineItems)
+	}
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	if expiresAt, set := d.GetOk("expires_at"); set {
+		expiresAtTime, err := time.Parse(time.RFC3339, ToString(expiresAt))
+		if err != nil {
+			return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", expiresAt)
+		}
+		params.ExpiresAt = stripe.Int64(expiresAtTime.Unix())
+	}
+	if collectionMethod, set := d.GetOk("collection_method"); set {
+		params.CollectionMethod = stripe.String(ToString(collectionMethod))
+	}
+	if taxRates, set := d.GetOk("default_tax_rates"); set {
+		params.DefaultTaxRates = stripe.StringSlice(ToStringSlice(taxRates))
+	}
+	if discounts, set := d.GetOk("discounts"); set {
+		params.Discounts = expandQuoteDiscounts(discounts)
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	quote, err := c.Quotes.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(quote.ID)
+	return resourceStripeQuoteRead(ctx, d, m)
+}
+
+func resourceStripeQuoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.QuoteParams{}
+
+	// line items sent without an ID replace all the existing ones
+	if d.HasChange("line_items") {
+		params.LineItems = expandQuoteLineItems(d.Get("line_items"))
+	}
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("expires_at") {
+		expiresAt := ExtractString(d, "expires_at")
+		expiresAtTime, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", expiresAt)
+		}
+		params.ExpiresAt = stripe.Int64(expiresAtTime.Unix())
+	}
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(ExtractString(d, "collection_method"))
+	}
+	if d.HasChange("default_tax_rates") {
+		params.DefaultTaxRates = stripe.StringSlice(ExtractStringSlice(d, "default_tax_rates"))
+	}
+	if d.HasChange("discounts") {
+		params.Discounts = expandQuoteDiscounts(d.Get("discounts"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Quotes.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeQuoteRead(ctx, d, m)
+}
+
+func resourceStripeQuoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	// the status in the state may be outdated, e.g. when the customer accepted the quote
+	quote, err := c.Quotes.Get(d.Id(), &stripe.QuoteParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	switch quote.Status {
+	case stripe.QuoteStatusDraft, stripe.QuoteStatusOpen:
+		_, err = c.Quotes.Cancel(d.Id(), &stripe.QuoteCancelParams{Params: stripe.Params{Context: ctx}})
+	default:
+		log.Printf("[WARN] Quote %s is %s and can't be canceled, removing it from the state",
+			d.Id(), quote.Status)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,41 @@
+package stripe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestResourceStripeQuoteDiff_expiresAt plans an expires_at written with an offset against the UTC time
+// the read stores, the same time is no change.
+func TestResourceStripeQuoteDiff_expiresAt(t *testing.T) {
+	state := &terraform.InstanceState{ID: "qt_123", Attributes: map[string]string{
+		"id":         "qt_123",
+		"expires_at": "2030-01-01T00:00:00Z",
+	}}
+	cases := []struct {
+		expiresAt string
+		changed   bool
+	}{
+		{"2030-01-01T00:00:00Z", false},
+		{"2030-01-01T01:00:00+01:00", false},
+		{"2030-01-02T00:00:00Z", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expiresAt, func(t *testing.T) {
+			raw := map[string]interface{}{"expires_at": tc.expiresAt}
+			diff, err := resourceStripeQuote().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), &Config{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			changed := false
+			if diff != nil {
+				_, changed = diff.Attributes["expires_at"]
+			}
+			if changed != tc.changed {
+				t.Errorf("expected expires_at changed %t, got %v", tc.changed, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeRadarValueList() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeRadarValueListRead,
+		CreateContext: resourceStripeRadarValueListCreate,
+		UpdateContext: resourceStripeRadarValueListUpdate,
+		DeleteContext: resourceStripeRadarValueListDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the value list for use in rules.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The human-readable name of the value list.",
+			},
+			"item_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(stripe.RadarValueListItemTypeString),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.RadarValueListItemTypeCardBin),
+					string(stripe.RadarValueListItemTypeCardFingerprint),
+					string(stripe.RadarValueListItemTypeCaseSensitiveString),
+					string(stripe.RadarValueListItemTypeCountry),
+					string(stripe.RadarValueListItemTypeEmail),
+					string(stripe.RadarValueListItemTypeIPAddress),
+					string(stripe.RadarValueListItemTypeString),
+				}, false),
+				Description: "Type of the items in the value list. One of card_fingerprint, card_bin, email, " +
+					"ip_address, country, string, or case_sensitive_string. Defaults to string.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+		},
+	}
+}
+
+func resourceStripeRadarValueListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	valueList, err := c.RadarValueLists.Get(d.Id(), &stripe.RadarValueListParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("alias", valueList.Alias),
+		d.Set("name", valueList.Name),
+		d.Set("item_type", valueList.ItemType),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(valueList.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
+
+func resourceStripeRadarValueListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.RadarValueListParams{
+		Alias:    stripe.String(ExtractString(d, "alias")),
+		Name:     stripe.String(ExtractString(d, "name")),
+		ItemType: stripe.String(ExtractString(d, "item_type")),
+	}
+
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	valueList, err := c.RadarValueLists.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(valueList.ID)
+	return resourceStripeRadarValueListRead(ctx, d, m)
+}
+
+func resourceStripeRadarValueListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.RadarValueListParams{}
+
+	if d.HasChange("name") {
+		params.Name = stripe.String(ExtractString(d, "name"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.RadarValueLists.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeRadarValueListRead(ctx, d, m)
+}
+
+func resourceStripeRadarValueListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	_, err := c.RadarValueLists.Del(d.Id(), &stripe.RadarValueListParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
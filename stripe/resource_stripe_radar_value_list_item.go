@@ -0,0 +1,109 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeRadarValueListItem() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeRadarValueListItemRead,
+		CreateContext: resourceStripeRadarValueListItemCreate,
+		DeleteContext: resourceStripeRadarValueListItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStripeRadarValueListItemImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"value_list": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier of the value list which the created item will be added to.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The value of the item, whose type must match the type of the parent value list.",
+			},
+		},
+	}
+}
+
+// resourceStripeRadarValueListItemImport expects the <value_list_id>:<item_id> format,
+// so the imported item can be checked against the list it is supposed to belong to.
+func resourceStripeRadarValueListItemImport(ctx context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <value_list_id>:<item_id>", d.Id())
+	}
+
+	d.SetId(parts[1])
+	if err := d.Set("value_list", parts[0]); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceStripeRadarValueListItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	item, err := c.RadarValueListItems.Get(d.Id(), &stripe.RadarValueListItemParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if valueList := ExtractString(d, "value_list"); valueList != "" && valueList != item.RadarValueList {
+		return diag.Errorf("value list item %s belongs to the value list %s, not %s", item.ID, item.RadarValueList, valueList)
+	}
+
+	return CallSet(
+		d.Set("value_list", item.RadarValueList),
+		d.Set("value", item.Value),
+	)
+}
+
+func resourceStripeRadarValueListItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.RadarValueListItemParams{
+		RadarValueList: stripe.String(ExtractString(d, "value_list")),
+		Value:          stripe.String(ExtractString(d, "value")),
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	item, err := c.RadarValueListItems.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(item.ID)
+	return resourceStripeRadarValueListItemRead(ctx, d, m)
+}
+
+func resourceStripeRadarValueListItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	_, err := c.RadarValueListItems.Del(d.Id(), &stripe.RadarValueListItemParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,146 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeReview() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeReviewRead,
+		CreateContext: resourceStripeReviewCreate,
+		UpdateContext: resourceStripeReviewUpdate,
+		DeleteContext: resourceStripeReviewDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"review": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The ID of the review to manage. " +
+					"Reviews are opened by Radar, they can't be created through the API.",
+			},
+			"approve": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Approve the review while it's open. " +
+					"An approved review is closed and can't be reopened, setting it back to false has no effect.",
+			},
+			"open": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If true, the review needs action.",
+			},
+			"reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The reason the review is currently open or closed, like rule, manual, " +
+					"approved, refunded, refunded_as_fraud or disputed.",
+			},
+			"payment_intent": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The PaymentIntent ID associated with this review, if one exists.",
+			},
+			"charge": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The charge associated with this review.",
+			},
+		},
+	}
+}
+
+func resourceStripeReviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	review, err := c.Reviews.Get(d.Id(), &stripe.ReviewParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("review", review.ID),
+		d.Set("open", review.Open),
+		d.Set("reason", review.Reason),
+		func() error {
+			if review.PaymentIntent != nil {
+				return d.Set("payment_intent", review.PaymentIntent.ID)
+			}
+			return nil
+		}(),
+		func() error {
+			if review.Charge != nil {
+				return d.Set("charge", review.Charge.ID)
+			}
+			return nil
+		}(),
+	)
+}
+
+// resourceStripeReviewCreate takes over a review Radar opened for one of the account's payments.
+func resourceStripeReviewCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	review, err := c.Reviews.Get(ExtractString(d, "review"), &stripe.ReviewParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(review.ID)
+	if ExtractBool(d, "approve") {
+		if diags := approveReview(ctx, c, review); diags.HasError() {
+			return diags
+		}
+	}
+	return resourceStripeReviewRead(ctx, d, m)
+}
+
+func resourceStripeReviewUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	if d.HasChange("approve") && ExtractBool(d, "approve") {
+		// the review may have been closed since the last refresh, e.g. by refunding the charge
+		review, err := c.Reviews.Get(d.Id(), &stripe.ReviewParams{Params: stripe.Params{Context: ctx}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if diags := approveReview(ctx, c, review); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceStripeReviewRead(ctx, d, m)
+}
+
+func resourceStripeReviewDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("[WARN] Stripe SDK doesn't support Review deletion through API!")
+	d.SetId("")
+	return nil
+}
+
+// approveReview approves the review unless it's already closed, closed reviews can't be approved anymore.
+func approveReview(ctx context.Context, c *client.API, review *stripe.Review) diag.Diagnostics {
+	if !review.Open {
+		log.Printf("[WARN] Review %s is already closed as %s, it can't be approved", review.ID, review.Reason)
+		return nil
+	}
+	if _, err := c.Reviews.Approve(review.ID, &stripe.ReviewApproveParams{Params: stripe.Params{Context: ctx}}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeSetupIntent() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeSetupIntentRead,
+		CreateContext: resourceStripeSetupIntentCreate,
+		UpdateContext: resourceStripeSetupIntentUpdate,
+		DeleteContext: resourceStripeSetupIntentDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "ID of the customer this setup intent belongs to, " +
+					"the payment method is attached to the customer once the setup succeeds.",
+			},
+			"payment_method_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The list of payment method types that this setup intent is allowed to set up. " +
+					"Defaults to card.",
+			},
+			"usage": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(stripe.SetupIntentUsageOffSession),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.SetupIntentUsageOffSession),
+					string(stripe.SetupIntentUsageOnSession),
+				}, false),
+				Description: "Indicates how the payment method is intended to be used in the future, " +
+					"either on_session or off_session. Defaults to off_session.",
+			},
+			"payment_method": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the payment method to attach to this setup intent.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Status of this setup intent, one of requires_payment_method, requires_confirmation, " +
+					"requires_action, processing, canceled, or succeeded.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The client secret of this setup intent, used on the client to complete the setup.",
+			},
+		},
+	}
+}
+
+func resourceStripeSetupIntentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	setupIntent, err := c.SetupIntents.Get(d.Id(), &stripe.SetupIntentParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		func() error {
+			if setupIntent.Customer != nil {
+				return d.Set("customer", setupIntent.Customer.ID)
+			}
+			return d.Set("customer", "")
+		}(),
+		d.Set("payment_method_types", setupIntent.PaymentMethodTypes),
+		d.Set("usage", setupIntent.Usage),
+		func() error {
+			if setupIntent.PaymentMethod != nil {
+				return d.Set("payment_method", setupIntent.PaymentMethod.ID)
+			}
+			return d.Set("payment_method", "")
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(setupIntent.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", setupIntent.Status),
+		d.Set("client_secret", setupIntent.ClientSecret),
+	)
+}
+
+func resourceStripeSetupIntentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SetupIntentParams{
+		Usage: stripe.String(ExtractString(d, "usage")),
+	}
+
+	if customer, set := d.GetOk("customer"); set {
+		params.Customer = stripe.String(ToString(customer))
+	}
+	if paymentMethodTypes, set := d.GetOk("payment_method_types"); set {
+		params.PaymentMethodTypes = stripe.StringSlice(ToStringSlice(paymentMethodTypes))
+	}
+	if paymentMethod, set := d.GetOk("payment_method"); set {
+		params.PaymentMethod = stripe.String(ToString(paymentMethod))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	setupIntent, err := c.SetupIntents.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(setupIntent.ID)
+	return resourceStripeSetupIntentRead(ctx, d, m)
+}
+
+func resourceStripeSetupIntentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SetupIntentParams{}
+
+	if d.HasChange("customer") {
+		params.Customer = stripe.String(ExtractString(d, "customer"))
+	}
+	if d.HasChange("payment_method_types") {
+		params.PaymentMethodTypes = stripe.StringSlice(ExtractStringSlice(d, "payment_method_types"))
+	}
+	if d.HasChange("payment_method") {
+		params.PaymentMethod = stripe.String(ExtractString(d, "payment_method"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.SetupIntents.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSetupIntentRead(ctx, d, m)
+}
+
+func resourceStripeSetupIntentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	// the status in the state may be outdated, e.g. when the customer completed the setup
+	setupIntent, err := c.SetupIntents.Get(d.Id(), &stripe.SetupIntentParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	switch setupIntent.Status {
+	case stripe.SetupIntentStatusRequiresPaymentMethod,
+		stripe.SetupIntentStatusRequiresConfirmation,
+		stripe.SetupIntentStatusRequiresAction:
+		_, err = c.SetupIntents.Cancel(d.Id(), &stripe.SetupIntentCancelParams{Params: stripe.Params{Context: ctx}})
+	default:
+		log.Printf("[WARN] Setup Intent %s is %s and can't be canceled, removing it from the state",
+			d.Id(), setupIntent.Status)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
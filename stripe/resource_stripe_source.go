@@ -0,0 +1,312 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// resourceStripeSource manages the legacy Sources API, which Stripe replaced by payment methods.
+func resourceStripeSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeSourceRead,
+		CreateContext: resourceStripeSourceCreate,
+		UpdateContext: resourceStripeSourceUpdate,
+		DeleteContext: resourceStripeSourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The type of the source, like ach_credit_transfer or three_d_secure. " +
+					"Legacy Sources are superseded by payment methods.",
+			},
+			"type_data": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Parameters specific to the source type, like card for a three_d_secure source.",
+			},
+			"amount": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Amount associated with the source, required for single-use sources.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO code for the currency associated with the source.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of a customer the source is attached to after it's created.",
+			},
+			"owner": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Information about the owner of the payment instrument.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Owner’s full name.",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Owner’s email address.",
+						},
+						"phone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Owner’s phone number.",
+						},
+						"address": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Owner’s address map with the fields: line1, line2, city, state, " +
+								"postal_code and country",
+						},
+					},
+				},
+			},
+			"redirect": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Parameters for sources with the redirect flow.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"return_url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "The URL the customer is redirected to after they authenticated or cancelled.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL provided to you to redirect a customer to as part of the redirect flow.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the redirect, one of pending, succeeded, failed or not_required.",
+						},
+					},
+				},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the source, one of canceled, chargeable, consumed, failed, or pending. " +
+					"Only chargeable sources can be used to create a charge.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The client secret of the source, used for client-side retrieval using a publishable key.",
+			},
+		},
+	}
+}
+
+func resourceStripeSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	source, err := c.Sources.Get(d.Id(), &stripe.SourceObjectParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("type", source.Type),
+		d.Set("amount", source.Amount),
+		d.Set("currency", source.Currency),
+		d.Set("customer", source.Customer),
+		func() error {
+			if source.Owner != nil {
+				return d.Set("owner", []map[string]interface{}{
+					{
+						"name":    source.Owner.Name,
+						"email":   source.Owner.Email,
+						"phone":   source.Owner.Phone,
+						"address": flattenAddressMap(source.Owner.Address),
+					},
+				})
+			}
+			return d.Set("owner", nil)
+		}(),
+		func() error {
+			if source.Redirect != nil {
+				return d.Set("redirect", []map[string]interface{}{
+					{
+						"return_url": source.Redirect.ReturnURL,
+						"url":        source.Redirect.URL,
+						"status":     source.Redirect.Status,
+					},
+				})
+			}
+			return d.Set("redirect", nil)
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(source.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", source.Status),
+		d.Set("client_secret", source.ClientSecret),
+	)
+}
+
+func resourceStripeSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SourceObjectParams{
+		Type: stripe.String(ExtractString(d, "type")),
+	}
+
+	if typeData, set := d.GetOk("type_data"); set {
+		params.TypeData = make(map[string]string)
+		for k, v := range ToMap(typeData) {
+			params.TypeData[k] = ToString(v)
+		}
+	}
+	if amount, set := d.GetOk("amount"); set {
+		params.Amount = stripe.Int64(ToInt64(amount))
+	}
+	if currency, set := d.GetOk("currency"); set {
+		params.Currency = stripe.String(ToString(currency))
+	}
+	if owner, set := d.GetOk("owner"); set {
+		params.Owner = expandSourceOwner(owner)
+	}
+	if redirect, set := d.GetOk("redirect"); set {
+		params.Redirect = &stripe.RedirectParams{
+			ReturnURL: stripe.String(ToString(ToMap(redirect)["return_url"])),
+		}
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	source, err := c.Sources.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(source.ID)
+
+	// the customer parameter of the source creation only applies to cloning sources on Connect,
+	// attaching is a separate call to the customer's sources
+	if customer, set := d.GetOk("customer"); set {
+		_, err := c.PaymentSource.New(&stripe.CustomerSourceParams{
+			Params:   stripe.Params{Context: ctx},
+			Customer: stripe.String(ToString(customer)),
+			Source:   &stripe.SourceParams{Token: stripe.String(source.ID)},
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeSourceRead(ctx, d, m)
+}
+
+func resourceStripeSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SourceObjectParams{}
+
+	if !d.HasChanges("owner", "metadata") {
+		return resourceStripeSourceRead(ctx, d, m)
+	}
+
+	if d.HasChange("owner") {
+		params.Owner = expandSourceOwner(d.Get("owner"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Sources.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSourceRead(ctx, d, m)
+}
+
+func resourceStripeSourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	customer := ExtractString(d, "customer")
+	if customer == "" {
+		log.Printf("[WARN] Source %s isn't attached to a customer and can't be deleted, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_, err := c.Sources.Detach(d.Id(), &stripe.SourceObjectDetachParams{
+		Params:   stripe.Params{Context: ctx},
+		Customer: stripe.String(customer),
+	})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandSourceOwner(value interface{}) *stripe.SourceOwnerParams {
+	owner := &stripe.SourceOwnerParams{}
+	ownerMap := ToMap(value)
+	if name := ToString(ownerMap["name"]); name != "" {
+		owner.Name = stripe.String(name)
+	}
+	if email := ToString(ownerMap["email"]); email != "" {
+		owner.Email = stripe.String(email)
+	}
+	if phone := ToString(ownerMap["phone"]); phone != "" {
+		owner.Phone = stripe.String(phone)
+	}
+	if address := ToMap(ownerMap["address"]); len(address) > 0 {
+		owner.Address = expandAddress(address)
+	}
+	return owner
+}
@@ -0,0 +1,314 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeSubscription() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeSubscriptionRead,
+		CreateContext: resourceStripeSubscriptionCreate,
+		UpdateContext: resourceStripeSubscriptionUpdate,
+		DeleteContext: resourceStripeSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier of the customer to subscribe.",
+			},
+			"items": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Description: "The prices the customer is subscribed to, an item removed from the list " +
+					"is deleted from the subscription.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the subscription item.",
+						},
+						"price": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the price the item is billed at.",
+						},
+						"quantity": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "The quantity of the price the customer is subscribed to.",
+						},
+					},
+				},
+			},
+			"cancel_at_period_end": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the subscription is canceled at the end of the current period.",
+			},
+			"pause_collection": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Pauses the collection of payments, removing the block resumes the collection. " +
+					"The subscription stays active while paused.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"behavior": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.SubscriptionPauseCollectionBehaviorKeepAsDraft),
+								string(stripe.SubscriptionPauseCollectionBehaviorMarkUncollectible),
+								string(stripe.SubscriptionPauseCollectionBehaviorVoid),
+							}, false),
+							Description: "The behavior of the invoices created while paused, " +
+								"one of keep_as_draft, mark_uncollectible, or void.",
+						},
+						"resumes_at": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validateRFC3339,
+							DiffSuppressFunc: suppressEquivalentTimestamps,
+							Description: "The time after which the subscription resumes collecting payments, " +
+								"in RFC3339 format. If not set, the collection stays paused until the block is removed.",
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the subscription, one of incomplete, incomplete_expired, trialing, " +
+					"active, past_due, canceled, or unpaid.",
+			},
+			"current_period_end": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End of the current period that the subscription has been invoiced for, in RFC3339 format.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+		},
+	}
+}
+
+func resourceStripeSubscriptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	subscription, err := c.Subscriptions.Get(d.Id(), &stripe.SubscriptionParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		func() error {
+			if subscription.Customer != nil {
+				return d.Set("customer", subscription.Customer.ID)
+			}
+			return nil
+		}(),
+		func() error {
+			var items []map[string]interface{}
+			if subscription.Items != nil {
+				for _, item := range subscription.Items.Data {
+					itemMap := map[string]interface{}{
+						"id":       item.ID,
+						"quantity": item.Quantity,
+					}
+					if item.Price != nil {
+						itemMap["price"] = item.Price.ID
+					}
+					items = append(items, itemMap)
+				}
+			}
+			return d.Set("items", items)
+		}(),
+		d.Set("cancel_at_period_end", subscription.CancelAtPeriodEnd),
+		d.Set("pause_collection", flattenSubscriptionPauseCollection(subscription.PauseCollection)),
+		d.Set("status", subscription.Status),
+		d.Set("current_period_end", ToRFC3339(subscription.CurrentPeriodEnd)),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(subscription.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
+
+func resourceStripeSubscriptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SubscriptionParams{
+		Customer:          stripe.String(ExtractString(d, "customer")),
+		Items:             expandSubscriptionItems(nil, d.Get("items")),
+		CancelAtPeriodEnd: stripe.Bool(ExtractBool(d, "cancel_at_period_end")),
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	subscription, err := c.Subscriptions.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subscription.ID)
+
+	// Stripe only pauses the collection of an existing subscription
+	if pauseCollection, set := d.GetOk("pause_collection"); set {
+		updateParams := &stripe.SubscriptionParams{}
+		updateParams.PauseCollection, err = expandSubscriptionPauseCollection(pauseCollection)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		updateParams.Context = ctx
+		if _, err := c.Subscriptions.Update(d.Id(), updateParams); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeSubscriptionRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SubscriptionParams{}
+
+	if !d.HasChanges("items", "cancel_at_period_end", "pause_collection", "metadata") {
+		return resourceStripeSubscriptionRead(ctx, d, m)
+	}
+
+	if d.HasChange("items") {
+		oldItems, newItems := d.GetChange("items")
+		params.Items = expandSubscriptionItems(oldItems, newItems)
+	}
+	if d.HasChange("cancel_at_period_end") {
+		params.CancelAtPeriodEnd = stripe.Bool(ExtractBool(d, "cancel_at_period_end"))
+	}
+	if d.HasChange("pause_collection") {
+		if pauseCollection, set := d.GetOk("pause_collection"); set {
+			var err error
+			params.PauseCollection, err = expandSubscriptionPauseCollection(pauseCollection)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			// an empty pause_collection resumes the collection of payments
+			params.AddExtra("pause_collection", "")
+		}
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Subscriptions.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSubscriptionRead(ctx, d, m)
+}
+
+// resourceStripeSubscriptionDelete cancels the subscription right away, without a final invoice.
+func resourceStripeSubscriptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	switch stripe.SubscriptionStatus(ExtractString(d, "status")) {
+	case stripe.SubscriptionStatusCanceled, stripe.SubscriptionStatusIncompleteExpired:
+		log.Printf("[WARN] Subscription %s has already ended, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_, err := c.Subscriptions.Cancel(d.Id(), &stripe.SubscriptionCancelParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandSubscriptionItems matches the items by position, an item keeps its ID when its price or quantity
+// changes and the items past the end of the new list are deleted.
+func expandSubscriptionItems(oldValue, newValue interface{}) []*stripe.SubscriptionItemsParams {
+	oldItems := ToSlice(oldValue)
+	var items []*stripe.SubscriptionItemsParams
+	for i, v := range ToSlice(newValue) {
+		item := ToMap(v)
+		params := &stripe.SubscriptionItemsParams{
+			Price: stripe.String(ToString(item["price"])),
+		}
+		if quantity := ToInt64(item["quantity"]); quantity > 0 {
+			params.Quantity = stripe.Int64(quantity)
+		}
+		if i < len(oldItems) {
+			params.ID = stripe.String(ToString(ToMap(oldItems[i])["id"]))
+		}
+		items = append(items, params)
+	}
+	for i := len(items); i < len(oldItems); i++ {
+		items = append(items, &stripe.SubscriptionItemsParams{
+			ID:      stripe.String(ToString(ToMap(oldItems[i])["id"])),
+			Deleted: stripe.Bool(true),
+		})
+	}
+	return items
+}
+
+func expandSubscriptionPauseCollection(value interface{}) (*stripe.SubscriptionPauseCollectionParams, error) {
+	pauseCollection := ToMap(ToSlice(value)[0])
+	params := &stripe.SubscriptionPauseCollectionParams{
+		Behavior: stripe.String(ToString(pauseCollection["behavior"])),
+	}
+	if resumesAt := ToString(pauseCollection["resumes_at"]); resumesAt != "" {
+		t, err := time.Parse(time.RFC3339, resumesAt)
+		if err != nil {
+			return nil, err
+		}
+		params.ResumesAt = stripe.Int64(t.Unix())
+	}
+	return params, nil
+}
+
+// flattenSubscriptionPauseCollection returns no block for a subscription that isn't paused,
+// the SDK decodes it into an empty pause_collection.
+func flattenSubscriptionPauseCollection(pauseCollection stripe.SubscriptionPauseCollection) []map[string]interface{} {
+	if pauseCollection.Behavior == "" {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"behavior":   pauseCollection.Behavior,
+			"resumes_at": ToRFC3339(pauseCollection.ResumesAt),
+		},
+	}
+}
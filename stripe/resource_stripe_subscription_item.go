@@ -0,0 +1,166 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeSubscriptionItem() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeSubscriptionItemRead,
+		CreateContext: resourceStripeSubscriptionItemCreate,
+		UpdateContext: resourceStripeSubscriptionItemUpdate,
+		DeleteContext: resourceStripeSubscriptionItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"subscription": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier of the subscription to modify.",
+			},
+			"price": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the price object.",
+			},
+			"quantity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The quantity you’d like to apply to the subscription item you’re creating.",
+			},
+			"proration_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.SubscriptionProrationBehaviorAlwaysInvoice),
+					string(stripe.SubscriptionProrationBehaviorCreateProrations),
+					string(stripe.SubscriptionProrationBehaviorNone),
+				}, false),
+				Description: "Determines how to handle prorations when the billing cycle changes, " +
+					"one of always_invoice, create_prorations, or none. Applies to every change, including the removal.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+		},
+	}
+}
+
+func resourceStripeSubscriptionItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	item, err := c.SubscriptionItems.Get(d.Id(), &stripe.SubscriptionItemParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("subscription", item.Subscription),
+		func() error {
+			if item.Price != nil {
+				return d.Set("price", item.Price.ID)
+			}
+			return nil
+		}(),
+		d.Set("quantity", item.Quantity),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(item.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
+
+func resourceStripeSubscriptionItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SubscriptionItemParams{
+		Subscription: stripe.String(ExtractString(d, "subscription")),
+		Price:        stripe.String(ExtractString(d, "price")),
+	}
+
+	if quantity, set := d.GetOk("quantity"); set {
+		params.Quantity = stripe.Int64(ToInt64(quantity))
+	}
+	if prorationBehavior, set := d.GetOk("proration_behavior"); set {
+		params.ProrationBehavior = stripe.String(ToString(prorationBehavior))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	item, err := c.SubscriptionItems.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(item.ID)
+	return resourceStripeSubscriptionItemRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionItemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SubscriptionItemParams{}
+
+	// proration_behavior only affects the changes below, on its own there is nothing to send
+	if !d.HasChanges("price", "quantity", "metadata") {
+		return resourceStripeSubscriptionItemRead(ctx, d, m)
+	}
+
+	if d.HasChange("price") {
+		params.Price = stripe.String(ExtractString(d, "price"))
+	}
+	if d.HasChange("quantity") {
+		params.Quantity = stripe.Int64(ExtractInt64(d, "quantity"))
+	}
+	if prorationBehavior, set := d.GetOk("proration_behavior"); set {
+		params.ProrationBehavior = stripe.String(ToString(prorationBehavior))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.SubscriptionItems.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSubscriptionItemRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SubscriptionItemParams{}
+	if prorationBehavior, set := d.GetOk("proration_behavior"); set {
+		params.ProrationBehavior = stripe.String(ToString(prorationBehavior))
+	}
+
+	params.Context = ctx
+	_, err := c.SubscriptionItems.Del(d.Id(), params)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,282 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeSubscriptionSchedule() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeSubscriptionScheduleRead,
+		CreateContext: resourceStripeSubscriptionScheduleCreate,
+		UpdateContext: resourceStripeSubscriptionScheduleUpdate,
+		DeleteContext: resourceStripeSubscriptionScheduleDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier of the customer to create the subscription schedule for.",
+			},
+			"start_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.Any(
+					validation.StringInSlice([]string{"now"}, false),
+					validateRFC3339,
+				),
+				Description: "When the subscription schedule starts, either now or a date in the RFC3339 format. " +
+					"Defaults to now.",
+			},
+			"end_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.SubscriptionScheduleEndBehaviorCancel),
+					string(stripe.SubscriptionScheduleEndBehaviorRelease),
+				}, false),
+				Description: "Behavior of the subscription schedule and underlying subscription when it ends. " +
+					"Possible values are release and cancel.",
+			},
+			"phases": {
+				Type:     schema.TypeList,
+				Required: true,
+				Description: "List representing phases of the subscription schedule. " +
+					"Each phase can be customized to have different durations, plans, and coupons.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"items": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "List of configuration items, each with an attached price, to apply during this phase.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"price": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The ID of the price object.",
+									},
+									"quantity": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     1,
+										Description: "Quantity for the given price.",
+									},
+								},
+							},
+						},
+						"iterations": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Description: "Integer representing the multiplier applied to the price interval. " +
+								"For example, iterations=2 applied to a price with interval=month and interval_count=3 " +
+								"results in a phase of duration 2 * 3 months = 6 months.",
+						},
+						"coupon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The identifier of the coupon to apply to this phase of the subscription schedule.",
+						},
+						"trial": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "If set to true the entire phase is counted as a trial and the customer will not be charged.",
+						},
+					},
+				},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The present status of the subscription schedule. " +
+					"Possible values are not_started, active, completed, released, and canceled.",
+			},
+			"subscription": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the subscription managed by the subscription schedule.",
+			},
+		},
+	}
+}
+
+func resourceStripeSubscriptionScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	schedule, err := c.SubscriptionSchedules.Get(d.Id(), &stripe.SubscriptionScheduleParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// phases aren't read back: Stripe turns iterations and trial into end dates,
+	// so the API representation can't be mapped onto the configured blocks
+	return CallSet(
+		d.Set("customer", schedule.Customer.ID),
+		d.Set("end_behavior", schedule.EndBehavior),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(schedule.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", schedule.Status),
+		d.Set("subscription", func() string {
+			if schedule.Subscription != nil {
+				return schedule.Subscription.ID
+			}
+			return ""
+		}()),
+	)
+}
+
+func resourceStripeSubscriptionScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SubscriptionScheduleParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+		Phases:   expandSubscriptionSchedulePhases(d.Get("phases")),
+	}
+
+	startDate := ExtractString(d, "start_date")
+	switch startDate {
+	case "", "now":
+		params.StartDateNow = stripe.Bool(true)
+	default:
+		startDateTime, err := time.Parse(time.RFC3339, startDate)
+		if err != nil {
+			return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", startDate)
+		}
+		params.StartDate = stripe.Int64(startDateTime.Unix())
+	}
+	if endBehavior, set := d.GetOk("end_behavior"); set {
+		params.EndBehavior = stripe.String(ToString(endBehavior))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	params.Context = ctx
+	setIdempotencyKey(d, &params.Params)
+	schedule, err := c.SubscriptionSchedules.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schedule.ID)
+	return resourceStripeSubscriptionScheduleRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.SubscriptionScheduleParams{}
+
+	if d.HasChange("end_behavior") {
+		params.EndBehavior = stripe.String(ExtractString(d, "end_behavior"))
+	}
+	if d.HasChange("phases") {
+		params.Phases = expandSubscriptionSchedulePhases(d.Get("phases"))
+
+		// Stripe requires the start of the phase already in progress to stay untouched
+		schedule, err := c.SubscriptionSchedules.Get(d.Id(), &stripe.SubscriptionScheduleParams{Params: stripe.Params{Context: ctx}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(schedule.Phases) > 0 && len(params.Phases) > 0 {
+			params.Phases[0].StartDate = stripe.Int64(schedule.Phases[0].StartDate)
+		}
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.SubscriptionSchedules.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSubscriptionScheduleRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	switch stripe.SubscriptionScheduleStatus(ExtractString(d, "status")) {
+	case stripe.SubscriptionScheduleStatusCanceled,
+		stripe.SubscriptionScheduleStatusCompleted,
+		stripe.SubscriptionScheduleStatusReleased:
+		log.Printf("[WARN] Subscription Schedule %s has already ended, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_, err := c.SubscriptionSchedules.Cancel(d.Id(), &stripe.SubscriptionScheduleCancelParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandSubscriptionSchedulePhases(value interface{}) []*stripe.SubscriptionSchedulePhaseParams {
+	var phases []*stripe.SubscriptionSchedulePhaseParams
+	for _, p := range ToSlice(value) {
+		phase := &stripe.SubscriptionSchedulePhaseParams{}
+		for k, v := range ToMap(p) {
+			switch k {
+			case "items":
+				for _, i := range ToSlice(v) {
+					item := &stripe.SubscriptionSchedulePhaseItemParams{}
+					for ik, iv := range ToMap(i) {
+						switch ik {
+						case "price":
+							item.Price = stripe.String(ToString(iv))
+						case "quantity":
+							item.Quantity = stripe.Int64(ToInt64(iv))
+						}
+					}
+					phase.Items = append(phase.Items, item)
+				}
+			case "iterations":
+				if iterations := ToInt64(v); iterations != 0 {
+					phase.Iterations = stripe.Int64(iterations)
+				}
+			case "coupon":
+				if coupon := ToString(v); coupon != "" {
+					phase.Coupon = stripe.String(coupon)
+				}
+			case "trial":
+				if trial := ToBool(v); trial {
+					phase.Trial = stripe.Bool(trial)
+				}
+			}
+		}
+		phases = append(phases, phase)
+	}
+	return phases
+}
@@ -0,0 +1,85 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestResourceStripeSubscription_pauseCollection pauses a subscription and resumes it again by removing the block,
+// the fake Stripe keeps the pause_collection the requests set.
+func TestResourceStripeSubscription_pauseCollection(t *testing.T) {
+	var requests []string
+	pauseCollection := "null"
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if r.Method == http.MethodPost {
+			requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm))
+			switch {
+			case r.PostForm.Has("pause_collection[behavior]"):
+				pauseCollection = fmt.Sprintf(`{"behavior": %q, "resumes_at": %s}`,
+					r.PostForm.Get("pause_collection[behavior]"), r.PostForm.Get("pause_collection[resumes_at]"))
+			case r.PostForm.Has("pause_collection"):
+				pauseCollection = "null"
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+  "id": "sub_123", "object": "subscription", "customer": "cus_123", "status": "active",
+  "items": {"object": "list", "data": [{"id": "si_123", "price": {"id": "price_123"}, "quantity": 1}]},
+  "pause_collection": %s
+}`, pauseCollection)
+	})
+	config := &Config{API: api}
+	r := resourceStripeSubscription()
+
+	apply := func(state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+		t.Helper()
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		state, diags := r.Apply(context.Background(), state, diff, config)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return state
+	}
+
+	subscription := map[string]interface{}{
+		"customer":        "cus_123",
+		"items":           []interface{}{map[string]interface{}{"price": "price_123"}},
+		"idempotency_key": "test",
+		"pause_collection": []interface{}{map[string]interface{}{
+			"behavior":   "keep_as_draft",
+			"resumes_at": "2027-01-01T00:00:00Z",
+		}},
+	}
+	state := apply(nil, subscription)
+	if behavior := state.Attributes["pause_collection.0.behavior"]; behavior != "keep_as_draft" {
+		t.Errorf("expected the collection paused with keep_as_draft, got %q", behavior)
+	}
+	if resumesAt := state.Attributes["pause_collection.0.resumes_at"]; resumesAt != ToRFC3339(1798761600) {
+		t.Errorf("expected the collection to resume at %s, got %q", ToRFC3339(1798761600), resumesAt)
+	}
+
+	delete(subscription, "pause_collection")
+	state = apply(state, subscription)
+	if count := state.Attributes["pause_collection.#"]; count != "0" {
+		t.Errorf("expected the collection resumed, got %s pause_collection blocks", count)
+	}
+
+	expected := []string{
+		"POST /v1/subscriptions map[cancel_at_period_end:[false] customer:[cus_123] items[0][price]:[price_123]]",
+		"POST /v1/subscriptions/sub_123 map[pause_collection[behavior]:[keep_as_draft] pause_collection[resumes_at]:[1798761600]]",
+		"POST /v1/subscriptions/sub_123 map[pause_collection:[]]",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
@@ -1,181 +1,212 @@
 package stripe
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripeTaxRate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStripeTaxRateCreate,
-		Read:   resourceStripeTaxRateRead,
-		Update: resourceStripeTaxRateUpdate,
-		Delete: resourceStripeTaxRateDelete,
+		ReadContext:   resourceStripeTaxRateRead,
+		CreateContext: resourceStripeTaxRateCreate,
+		UpdateContext: resourceStripeTaxRateUpdate,
+		DeleteContext: resourceStripeTaxRateDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-
 		Schema: map[string]*schema.Schema{
-			"active": &schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"active": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				Default: true,
-				Description: "Defaults to true. When set to false, this tax rate cannot be used with new applications or Checkout Sessions," +
-				"but will still work for subscriptions and invoices that already have it set.",
+				Default:  true,
+				Description: "Defaults to true. When set to false, " +
+					"this tax rate cannot be used with new applications or Checkout Sessions, " +
+					"but will still work for subscriptions and invoices that already have it set.",
 			},
-			"created": &schema.Schema{
-				Type:     schema.TypeInt,
-				Computed: true,
+			"created": {
+				Type:        schema.TypeInt,
+				Computed:    true,
 				Description: "Time at which the object was created. Measured in seconds since the Unix epoch.",
 			},
-			"description": &schema.Schema{
+			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Description: "An arbitrary string attached to the tax rate for your internal use only. It will not be visible to your customers.",
+				Description: "An arbitrary string attached to the tax rate for your internal use only. " +
+					"It will not be visible to your customers.",
 			},
-			"display_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+			"display_name": {
+				Type:        schema.TypeString,
+				Required:    true,
 				Description: "The display name of the tax rate, which will be shown to users.",
 			},
-			"inclusive": &schema.Schema{
-				Type:     schema.TypeBool,
-				Required: true,
+			"inclusive": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				ForceNew:    true,
 				Description: "This specifies if the tax rate is inclusive or exclusive.",
 			},
-			"jurisdiction": &schema.Schema{
+			"jurisdiction": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The jurisdiction for the tax rate. You can use this label field for tax reporting " +
+					"purposes. It also appears on your customer’s invoice.",
+			},
+			"percentage": {
+				Type:        schema.TypeFloat,
+				Required:    true,
+				ForceNew:    true,
+				Description: "This represents the tax rate percent out of 100.",
+			},
+			"country": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Two-letter country code (ISO 3166-1 alpha-2).",
+			},
+			"state": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Description: "The jurisdiction for the tax rate. You can use this label field for tax reporting purposes. It also appears on your customer’s invoice.",
+				ForceNew: true,
+				Description: "ISO 3166-2 subdivision code, without country prefix. " +
+					"For example, “NY” for New York, United States.",
 			},
-			"livemode": &schema.Schema{
+			"tax_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The high-level tax type, such as vat, gst, sales_tax or custom. " +
+					"This field is used for reporting purposes only.",
+			},
+			"livemode": {
 				Type:     schema.TypeBool,
 				Computed: true,
-				Description: "Has the value true if the object exists in live mode or the value false if the object exists in test mode.",
+				Description: "Has the value true if the object exists in live mode " +
+					"or the value false if the object exists in test mode.",
 			},
-			"metadata": &schema.Schema{
-				Type: schema.TypeMap,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+			"metadata": {
+				Type:     schema.TypeMap,
 				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 				Description: "Set of key-value pairs that you can attach to an object. " +
-				"This can be useful for storing additional information about the object in a structured format.",
-			},
-			"percentage": &schema.Schema{
-				Type:     schema.TypeFloat,
-				Required: true,
-				Description: "This represents the tax rate percent out of 100.",
+					"This can be useful for storing additional information about the object in a structured format.",
 			},
 		},
 	}
 }
 
-func resourceStripeTaxRateCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	taxRateDisplayName := d.Get("display_name").(string)
-	taxRateInclusive := d.Get("inclusive").(bool)
-	taxRatePercentage := d.Get("percentage").(float64)
-	params := &stripe.TaxRateParams{
-		DisplayName: stripe.String(taxRateDisplayName),
-		Inclusive:   stripe.Bool(taxRateInclusive),
-		Percentage:  stripe.Float64(taxRatePercentage),
+func resourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	taxRate, err := c.TaxRates.Get(d.Id(), &stripe.TaxRateParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
-	if active, ok := d.GetOk("active"); ok {
-		params.Active = stripe.Bool(active.(bool))
-	}
+	return CallSet(
+		d.Set("active", taxRate.Active),
+		d.Set("created", taxRate.Created),
+		d.Set("description", taxRate.Description),
+		d.Set("display_name", taxRate.DisplayName),
+		d.Set("inclusive", taxRate.Inclusive),
+		d.Set("jurisdiction", taxRate.Jurisdiction),
+		d.Set("percentage", taxRate.Percentage),
+		d.Set("country", taxRate.Country),
+		d.Set("state", taxRate.State),
+		d.Set("tax_type", taxRate.TaxType),
+		d.Set("livemode", taxRate.Livemode),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(taxRate.Metadata, ExtractMap(d, "metadata"))),
+	)
+}
 
-	if description, ok := d.GetOk("description"); ok {
-		params.Description = stripe.String(description.(string))
+func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.TaxRateParams{
+		DisplayName: stripe.String(ExtractString(d, "display_name")),
+		Inclusive:   stripe.Bool(ExtractBool(d, "inclusive")),
+		Percentage:  stripe.Float64(ToFloat64(d.Get("percentage"))),
+		Active:      stripe.Bool(ExtractBool(d, "active")),
 	}
 
-	if jurisdiction, ok := d.GetOk("jurisdiction"); ok {
-		params.Jurisdiction = stripe.String(jurisdiction.(string))
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
 	}
-
-	if meta, set := d.GetOk("metadata"); set {
-		for k, v := range ToMap(meta) {
-			params.AddMetadata(k, ToString(v))
-		}
+	if jurisdiction, set := d.GetOk("jurisdiction"); set {
+		params.Jurisdiction = stripe.String(ToString(jurisdiction))
 	}
-
-	Tax, err := client.TaxRates.New(params)
-
-	if err == nil {
-		log.Printf("[INFO] Create Tax Rate: %s (%f)", Tax.ID, Tax.Percentage)
-		d.SetId(Tax.ID)
-		d.Set("display_name", Tax.DisplayName)
-		d.Set("inclusive", Tax.Inclusive)
-		d.Set("percentage", Tax.Percentage)
-		d.Set("created", Tax.Created)
-		d.Set("livemode", Tax.Livemode)
+	if country, set := d.GetOk("country"); set {
+		params.Country = stripe.String(ToString(country))
 	}
+	if state, set := d.GetOk("state"); set {
+		params.State = stripe.String(ToString(state))
+	}
+	if taxType, set := d.GetOk("tax_type"); set {
+		params.TaxType = stripe.String(ToString(taxType))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
 
-	return err
-}
-
-func resourceStripeTaxRateRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	Tax, err := client.TaxRates.Get(d.Id(), nil)
-
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	taxRate, err := c.TaxRates.New(params)
 	if err != nil {
-		d.SetId("")
-	} else {
-		d.Set("active", Tax.Active)
-		d.Set("created", Tax.Created)
-		d.Set("description", Tax.Description)
-		d.Set("display_name", Tax.DisplayName)
-		d.Set("inclusive", Tax.Inclusive)
-		d.Set("jurisdiction", Tax.Jurisdiction)
-		d.Set("livemode", Tax.Livemode)
-		d.Set("metadata", Tax.Metadata)
+		return diag.FromErr(err)
 	}
 
-	return err
+	log.Printf("[INFO] Create tax rate: %s (%f)", taxRate.ID, taxRate.Percentage)
+	d.SetId(taxRate.ID)
+	return resourceStripeTaxRateRead(ctx, d, m)
 }
 
-func resourceStripeTaxRateUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	params := stripe.TaxRateParams{}
+func resourceStripeTaxRateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.TaxRateParams{}
 
 	if d.HasChange("active") {
-		params.Active = stripe.Bool(d.Get("active").(bool))
+		params.Active = stripe.Bool(ExtractBool(d, "active"))
 	}
-
 	if d.HasChange("description") {
-		params.Description = stripe.String(d.Get("description").(string))
+		params.Description = stripe.String(ExtractString(d, "description"))
 	}
-
-	if d.HasChange("diplay_name") {
-		params.DisplayName = stripe.String(d.Get("display_name").(string))
+	if d.HasChange("display_name") {
+		params.DisplayName = stripe.String(ExtractString(d, "display_name"))
 	}
-
 	if d.HasChange("jurisdiction") {
-		params.Jurisdiction = stripe.String(d.Get("jurisdiction").(string))
+		params.Jurisdiction = stripe.String(ExtractString(d, "jurisdiction"))
 	}
-
 	if d.HasChange("metadata") {
-		params.Metadata = nil
-		metadata := ExtractMap(d, "metadata")
-		for k, v := range metadata {
-			params.AddMetadata(k, ToString(v))
-		}
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
 	}
 
-	_, err := client.TaxRates.Update(d.Id(), &params)
-
+	params.Context = ctx
+	_, err := c.TaxRates.Update(d.Id(), params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceStripeTaxRateRead(d, m)
+	return resourceStripeTaxRateRead(ctx, d, m)
 }
 
-func resourceStripeTaxRateDelete(d *schema.ResourceData, m interface{}) error {
-	return fmt.Errorf("[WARNING] Stripe doesn't allow deleting tax rates via the API.  Your state file contains at least one (\"%v\") that needs deletion.  Please remove it manually.", d.Get("display_name"))
+func resourceStripeTaxRateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.TaxRateParams{}
+	params.Active = stripe.Bool(false)
+	params.Context = ctx
+	_, err := c.TaxRates.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
 }
@@ -0,0 +1,197 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// the pinned Stripe SDK predates the tax settings API, these mirror the parts of it the resource uses
+type taxSettingsDefaultsParams struct {
+	TaxBehavior *string `form:"tax_behavior"`
+	TaxCode     *string `form:"tax_code"`
+}
+
+type taxSettingsHeadOfficeParams struct {
+	Address *stripe.AddressParams `form:"address"`
+}
+
+type taxSettingsParams struct {
+	stripe.Params `form:"*"`
+	Defaults      *taxSettingsDefaultsParams   `form:"defaults"`
+	HeadOffice    *taxSettingsHeadOfficeParams `form:"head_office"`
+}
+
+type taxSettings struct {
+	stripe.APIResource
+	Defaults struct {
+		TaxBehavior string `json:"tax_behavior"`
+		TaxCode     string `json:"tax_code"`
+	} `json:"defaults"`
+	HeadOffice *struct {
+		Address *stripe.Address `json:"address"`
+	} `json:"head_office"`
+	Status string `json:"status"`
+}
+
+// taxSettingsID is the ID of the singleton, the tax settings object doesn't have one
+const taxSettingsID = "tax_settings"
+
+func resourceStripeTaxSettings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeTaxSettingsRead,
+		CreateContext: resourceStripeTaxSettingsCreate,
+		UpdateContext: resourceStripeTaxSettingsUpdate,
+		DeleteContext: resourceStripeTaxSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Always tax_settings, the settings exist once per account.",
+			},
+			"defaults": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Default configuration to be used on Stripe Tax calculations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tax_behavior": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"inclusive",
+								"exclusive",
+								"inferred_by_currency",
+							}, false),
+							Description: "Specifies the default tax behavior to be used for tax calculations, " +
+								"one of inclusive, exclusive, or inferred_by_currency.",
+						},
+						"tax_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "A tax code ID used as the default on Stripe Tax calculations.",
+						},
+					},
+				},
+			},
+			"head_office": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The place where your business is located, address map with the fields: " +
+					"line1, line2, city, state, postal_code and country",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The active status component of the specified tax settings, either active or pending.",
+			},
+		},
+	}
+}
+
+func resourceStripeTaxSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	settings := &taxSettings{}
+	err := rawCall(m, http.MethodGet, "/v1/tax/settings", &stripe.Params{Context: ctx}, settings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(taxSettingsID)
+	return CallSet(
+		d.Set("defaults", []map[string]interface{}{
+			{
+				"tax_behavior": settings.Defaults.TaxBehavior,
+				"tax_code":     settings.Defaults.TaxCode,
+			},
+		}),
+		func() error {
+			if settings.HeadOffice != nil {
+				return d.Set("head_office", flattenAddressMap(settings.HeadOffice.Address))
+			}
+			return d.Set("head_office", nil)
+		}(),
+		d.Set("status", settings.Status),
+	)
+}
+
+// resourceStripeTaxSettingsCreate takes over the existing settings of the account,
+// the settings can't be created, only updated.
+func resourceStripeTaxSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &taxSettingsParams{}
+
+	if defaults, set := d.GetOk("defaults"); set {
+		params.Defaults = expandTaxSettingsDefaults(defaults)
+	}
+	if headOffice, set := d.GetOk("head_office"); set {
+		params.HeadOffice = expandTaxSettingsHeadOffice(headOffice)
+	}
+
+	params.Context = ctx
+	err := rawCall(m, http.MethodPost, "/v1/tax/settings", params, &taxSettings{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(taxSettingsID)
+	return resourceStripeTaxSettingsRead(ctx, d, m)
+}
+
+func resourceStripeTaxSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &taxSettingsParams{}
+
+	if d.HasChange("defaults") {
+		params.Defaults = expandTaxSettingsDefaults(d.Get("defaults"))
+	}
+	if d.HasChange("head_office") {
+		params.HeadOffice = expandTaxSettingsHeadOffice(d.Get("head_office"))
+	}
+
+	params.Context = ctx
+	err := rawCall(m, http.MethodPost, "/v1/tax/settings", params, &taxSettings{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTaxSettingsRead(ctx, d, m)
+}
+
+func resourceStripeTaxSettingsDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("[WARN] Stripe doesn't support Tax Settings deletion, the settings are kept as they are!")
+	d.SetId("")
+	return nil
+}
+
+func expandTaxSettingsDefaults(value interface{}) *taxSettingsDefaultsParams {
+	defaults := &taxSettingsDefaultsParams{}
+	for k, v := range ToMap(value) {
+		switch k {
+		case "tax_behavior":
+			if taxBehavior := ToString(v); taxBehavior != "" {
+				defaults.TaxBehavior = stripe.String(taxBehavior)
+			}
+		case "tax_code":
+			if taxCode := ToString(v); taxCode != "" {
+				defaults.TaxCode = stripe.String(taxCode)
+			}
+		}
+	}
+	return defaults
+}
+
+func expandTaxSettingsHeadOffice(value interface{}) *taxSettingsHeadOfficeParams {
+	return &taxSettingsHeadOfficeParams{Address: expandAddress(value)}
+}
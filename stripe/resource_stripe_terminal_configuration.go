@@ -0,0 +1,262 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+// stripe-go v72 has no terminal configurations client, the requests are sent through the backend directly
+type terminalConfigurationBBPOSWisePOSEParams struct {
+	Splashscreen *string `form:"splashscreen"`
+}
+
+type terminalConfigurationTippingParams struct {
+	FixedAmounts      []*int64 `form:"fixed_amounts"`
+	Percentages       []*int64 `form:"percentages"`
+	SmartTipThreshold *int64   `form:"smart_tip_threshold"`
+}
+
+type terminalConfigurationParams struct {
+	stripe.Params `form:"*"`
+	Name          *string                                        `form:"name"`
+	BBPOSWisePOSE *terminalConfigurationBBPOSWisePOSEParams      `form:"bbpos_wisepos_e"`
+	Tipping       map[string]*terminalConfigurationTippingParams `form:"tipping"`
+}
+
+type terminalConfigurationTipping struct {
+	FixedAmounts      []int64 `json:"fixed_amounts"`
+	Percentages       []int64 `json:"percentages"`
+	SmartTipThreshold int64   `json:"smart_tip_threshold"`
+}
+
+type terminalConfiguration struct {
+	stripe.APIResource
+	ID            string `json:"id"`
+	BBPOSWisePOSE *struct {
+		Splashscreen string `json:"splashscreen"`
+	} `json:"bbpos_wisepos_e"`
+	IsAccountDefault bool                                     `json:"is_account_default"`
+	Name             string                                   `json:"name"`
+	Tipping          map[string]*terminalConfigurationTipping `json:"tipping"`
+}
+
+func resourceStripeTerminalConfiguration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeTerminalConfigurationRead,
+		CreateContext: resourceStripeTerminalConfigurationCreate,
+		UpdateContext: resourceStripeTerminalConfigurationUpdate,
+		DeleteContext: resourceStripeTerminalConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the configuration.",
+			},
+			"bbpos_wisepos_e": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Settings specific to the BBPOS WisePOS E reader.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"splashscreen": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of a file with purpose terminal_reader_splashscreen shown on the reader when idle.",
+						},
+					},
+				},
+			},
+			"tipping": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tipping configurations for readers supporting on-reader tips, one per currency.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCurrency,
+							Description:  "Three-letter ISO currency code, in lowercase, the tips are configured for.",
+						},
+						"fixed_amounts": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    3,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Fixed amounts displayed when collecting a tip, in the smallest currency unit.",
+						},
+						"percentages": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    3,
+							Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntBetween(0, 100)},
+							Description: "Percentages displayed when collecting a tip.",
+						},
+						"smart_tip_threshold": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Description: "Below this amount, fixed amounts will be displayed, " +
+								"above it percentages will be displayed.",
+						},
+					},
+				},
+			},
+			"is_account_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether this configuration is the default of the account.",
+			},
+		},
+	}
+}
+
+func resourceStripeTerminalConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	configuration := &terminalConfiguration{}
+	path := stripe.FormatURLPath("/v1/terminal/configurations/%s", d.Id())
+	err := rawCall(m, http.MethodGet, path, &stripe.Params{Context: ctx}, configuration)
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("name", configuration.Name),
+		func() error {
+			if configuration.BBPOSWisePOSE != nil {
+				return d.Set("bbpos_wisepos_e", []map[string]interface{}{
+					{
+						"splashscreen": configuration.BBPOSWisePOSE.Splashscreen,
+					},
+				})
+			}
+			return d.Set("bbpos_wisepos_e", nil)
+		}(),
+		func() error {
+			var tipping []interface{}
+			for currency, tips := range configuration.Tipping {
+				if tips == nil {
+					continue
+				}
+				tipping = append(tipping, map[string]interface{}{
+					"currency":            currency,
+					"fixed_amounts":       tips.FixedAmounts,
+					"percentages":         tips.Percentages,
+					"smart_tip_threshold": tips.SmartTipThreshold,
+				})
+			}
+			return d.Set("tipping", tipping)
+		}(),
+		d.Set("is_account_default", configuration.IsAccountDefault),
+	)
+}
+
+func resourceStripeTerminalConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &terminalConfigurationParams{}
+
+	if name, set := d.GetOk("name"); set {
+		params.Name = stripe.String(ToString(name))
+	}
+	if bbposWisePOSE, set := d.GetOk("bbpos_wisepos_e"); set {
+		params.BBPOSWisePOSE = expandTerminalConfigurationBBPOSWisePOSE(bbposWisePOSE)
+	}
+	if tipping, set := d.GetOk("tipping"); set {
+		params.Tipping = expandTerminalConfigurationTipping(tipping)
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	configuration := &terminalConfiguration{}
+	err := rawCall(m, http.MethodPost, "/v1/terminal/configurations", params, configuration)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(configuration.ID)
+	return resourceStripeTerminalConfigurationRead(ctx, d, m)
+}
+
+func resourceStripeTerminalConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	params := &terminalConfigurationParams{}
+
+	if !d.HasChanges("name", "bbpos_wisepos_e", "tipping") {
+		return resourceStripeTerminalConfigurationRead(ctx, d, m)
+	}
+
+	if d.HasChange("name") {
+		params.Name = stripe.String(ExtractString(d, "name"))
+	}
+	if d.HasChange("bbpos_wisepos_e") {
+		params.BBPOSWisePOSE = expandTerminalConfigurationBBPOSWisePOSE(d.Get("bbpos_wisepos_e"))
+	}
+	if d.HasChange("tipping") {
+		params.Tipping = expandTerminalConfigurationTipping(d.Get("tipping"))
+	}
+
+	path := stripe.FormatURLPath("/v1/terminal/configurations/%s", d.Id())
+	params.Context = ctx
+	err := rawCall(m, http.MethodPost, path, params, &terminalConfiguration{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTerminalConfigurationRead(ctx, d, m)
+}
+
+func resourceStripeTerminalConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	path := stripe.FormatURLPath("/v1/terminal/configurations/%s", d.Id())
+	err := rawCall(m, http.MethodDelete, path, &stripe.Params{Context: ctx}, &terminalConfiguration{})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandTerminalConfigurationBBPOSWisePOSE sends an empty splashscreen to clear a removed one.
+func expandTerminalConfigurationBBPOSWisePOSE(value interface{}) *terminalConfigurationBBPOSWisePOSEParams {
+	return &terminalConfigurationBBPOSWisePOSEParams{
+		Splashscreen: stripe.String(ToString(ToMap(value)["splashscreen"])),
+	}
+}
+
+// expandTerminalConfigurationTipping keys the tipping blocks by their currency, the way the API expects them.
+func expandTerminalConfigurationTipping(value interface{}) map[string]*terminalConfigurationTippingParams {
+	tipping := map[string]*terminalConfigurationTippingParams{}
+	for _, t := range value.(*schema.Set).List() {
+		tips := &terminalConfigurationTippingParams{}
+		tipsMap := ToMap(t)
+		for _, amount := range ToIntSlice(tipsMap["fixed_amounts"]) {
+			tips.FixedAmounts = append(tips.FixedAmounts, stripe.Int64(amount))
+		}
+		for _, percentage := range ToIntSlice(tipsMap["percentages"]) {
+			tips.Percentages = append(tips.Percentages, stripe.Int64(percentage))
+		}
+		if threshold := ToInt64(tipsMap["smart_tip_threshold"]); threshold != 0 {
+			tips.SmartTipThreshold = stripe.Int64(threshold)
+		}
+		tipping[ToString(tipsMap["currency"])] = tips
+	}
+	return tipping
+}
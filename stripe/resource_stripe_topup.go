@@ -0,0 +1,184 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeTopup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeTopupRead,
+		CreateContext: resourceStripeTopupCreate,
+		UpdateContext: resourceStripeTopupUpdate,
+		DeleteContext: resourceStripeTopupDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"amount": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "A positive integer representing how much to transfer.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The ID of a source to transfer funds from. " +
+					"Defaults to the bank account of the account.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary string attached to the object. Often useful for displaying to users.",
+			},
+			"statement_descriptor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(0, 15),
+				Description: "Extra information about a top-up for the source’s bank statement. " +
+					"Limited to 15 ASCII characters.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the top-up, one of canceled, failed, pending, " +
+					"reversed, or succeeded.",
+			},
+		},
+	}
+}
+
+func resourceStripeTopupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	topup, err := c.Topups.Get(d.Id(), &stripe.TopupParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("amount", topup.Amount),
+		d.Set("currency", topup.Currency),
+		func() error {
+			if topup.Source != nil {
+				return d.Set("source", topup.Source.ID)
+			}
+			return nil
+		}(),
+		d.Set("description", topup.Description),
+		d.Set("statement_descriptor", topup.StatementDescriptor),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(topup.Metadata, ExtractMap(d, "metadata"))),
+		d.Set("status", topup.Status),
+	)
+}
+
+func resourceStripeTopupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.TopupParams{
+		Amount:   stripe.Int64(ExtractInt64(d, "amount")),
+		Currency: stripe.String(ExtractString(d, "currency")),
+	}
+
+	if source, set := d.GetOk("source"); set {
+		params.Source = &stripe.SourceParams{Token: stripe.String(ToString(source))}
+	}
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	if statementDescriptor, set := d.GetOk("statement_descriptor"); set {
+		params.StatementDescriptor = stripe.String(ToString(statementDescriptor))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	topup, err := c.Topups.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(topup.ID)
+	return resourceStripeTopupRead(ctx, d, m)
+}
+
+func resourceStripeTopupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.TopupParams{}
+
+	if !d.HasChanges("description", "metadata") {
+		return resourceStripeTopupRead(ctx, d, m)
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Topups.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTopupRead(ctx, d, m)
+}
+
+func resourceStripeTopupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	// top-ups settle on their own, the status in the state may be outdated
+	topup, err := c.Topups.Get(d.Id(), &stripe.TopupParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if topup.Status != stripe.TopupStatusPending {
+		log.Printf("[WARN] Top-up %s is %s and can't be canceled, removing it from the state",
+			d.Id(), topup.Status)
+		d.SetId("")
+		return nil
+	}
+
+	_, err = c.Topups.Cancel(d.Id(), &stripe.TopupParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,212 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeTransfer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeTransferRead,
+		CreateContext: resourceStripeTransferCreate,
+		UpdateContext: resourceStripeTransferUpdate,
+		DeleteContext: resourceStripeTransferDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"amount": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "A positive integer in cents representing how much to transfer.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of a connected Stripe account the funds are transferred to.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary string attached to the object. Often useful for displaying to users.",
+			},
+			"transfer_group": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "A string that identifies this transaction as part of a group, " +
+					"e.g. together with the charges it pays out.",
+			},
+			"source_transaction": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The ID of a charge to use as the source of the funds, " +
+					"the transfer then succeeds even when the funds aren't available yet.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"balance_transaction": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the balance transaction that describes the impact of this transfer on your account balance.",
+			},
+			"reversed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the transfer has been fully reversed.",
+			},
+		},
+	}
+}
+
+func resourceStripeTransferRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	transfer, err := c.Transfers.Get(d.Id(), &stripe.TransferParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("amount", transfer.Amount),
+		d.Set("currency", transfer.Currency),
+		func() error {
+			if transfer.Destination != nil {
+				return d.Set("destination", transfer.Destination.ID)
+			}
+			return nil
+		}(),
+		d.Set("description", transfer.Description),
+		d.Set("transfer_group", transfer.TransferGroup),
+		func() error {
+			if transfer.SourceTransaction != nil {
+				return d.Set("source_transaction", transfer.SourceTransaction.ID)
+			}
+			return d.Set("source_transaction", "")
+		}(),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(transfer.Metadata, ExtractMap(d, "metadata"))),
+		func() error {
+			if transfer.BalanceTransaction != nil {
+				return d.Set("balance_transaction", transfer.BalanceTransaction.ID)
+			}
+			return nil
+		}(),
+		d.Set("reversed", transfer.Reversed),
+	)
+}
+
+func resourceStripeTransferCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.TransferParams{
+		Amount:      stripe.Int64(ExtractInt64(d, "amount")),
+		Currency:    stripe.String(ExtractString(d, "currency")),
+		Destination: stripe.String(ExtractString(d, "destination")),
+	}
+
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	if transferGroup, set := d.GetOk("transfer_group"); set {
+		params.TransferGroup = stripe.String(ToString(transferGroup))
+	}
+	if sourceTransaction, set := d.GetOk("source_transaction"); set {
+		params.SourceTransaction = stripe.String(ToString(sourceTransaction))
+	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	transfer, err := c.Transfers.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(transfer.ID)
+	return resourceStripeTransferRead(ctx, d, m)
+}
+
+func resourceStripeTransferUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.TransferParams{}
+
+	if !d.HasChanges("description", "metadata") {
+		return resourceStripeTransferRead(ctx, d, m)
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("metadata") {
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
+	}
+
+	params.Context = ctx
+	_, err := c.Transfers.Update(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTransferRead(ctx, d, m)
+}
+
+// resourceStripeTransferDelete reverses what is left of the transfer, which moves the funds
+// back from the connected account as long as its balance covers them.
+func resourceStripeTransferDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+
+	// reversals made outside of Terraform don't show up in the state
+	transfer, err := c.Transfers.Get(d.Id(), &stripe.TransferParams{Params: stripe.Params{Context: ctx}})
+	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if transfer.Reversed {
+		log.Printf("[WARN] Transfer %s is reversed already, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_, err = c.Reversals.New(&stripe.ReversalParams{
+		Params:   stripe.Params{Context: ctx},
+		Transfer: stripe.String(d.Id()),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,104 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeUsageRecord() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeUsageRecordRead,
+		CreateContext: resourceStripeUsageRecordCreate,
+		DeleteContext: resourceStripeUsageRecordDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"idempotency_key": idempotencyKeySchema(),
+			"subscription_item": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the subscription item the usage is reported for.",
+			},
+			"quantity": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The usage quantity for the specified timestamp.",
+			},
+			"timestamp": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateRFC3339,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+				Description: "The time the usage occurred, expected format is RFC3339. " +
+					"Defaults to the time the record is created.",
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  stripe.UsageRecordActionIncrement,
+				ValidateFunc: validation.StringInSlice([]string{
+					stripe.UsageRecordActionIncrement,
+					stripe.UsageRecordActionSet,
+				}, false),
+				Description: "Either increment, which adds the quantity to the usage at the timestamp, " +
+					"or set, which overwrites it. Defaults to increment.",
+			},
+		},
+	}
+}
+
+// resourceStripeUsageRecordRead keeps the state as it is,
+// Stripe only exposes usage records aggregated into summaries.
+func resourceStripeUsageRecordRead(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceStripeUsageRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
+	params := &stripe.UsageRecordParams{
+		SubscriptionItem: stripe.String(ExtractString(d, "subscription_item")),
+		Quantity:         stripe.Int64(ExtractInt64(d, "quantity")),
+		Action:           stripe.String(ExtractString(d, "action")),
+	}
+
+	if timestamp, set := d.GetOk("timestamp"); set {
+		timestampTime, err := time.Parse(time.RFC3339, ToString(timestamp))
+		if err != nil {
+			return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", timestamp)
+		}
+		params.Timestamp = stripe.Int64(timestampTime.Unix())
+	}
+
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
+	usageRecord, err := c.UsageRecords.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(usageRecord.ID)
+	return CallSet(
+		d.Set("timestamp", ToRFC3339(usageRecord.Timestamp)),
+	)
+}
+
+func resourceStripeUsageRecordDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("[WARN] Stripe SDK doesn't support Usage Record deletion through API!")
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,48 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestResourceStripeUsageRecord_timestampOffset reports usage at a time with an offset, Stripe returns the Unix
+// timestamp which is stored in UTC. The next plan must not replace the record, it would report the usage twice.
+func TestResourceStripeUsageRecord_timestampOffset(t *testing.T) {
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "mbur_123", "object": "usage_record", "quantity": 10,
+			"subscription_item": "si_123", "timestamp": 1893456000}`)
+	})
+	config := &Config{API: api}
+	r := resourceStripeUsageRecord()
+	raw := map[string]interface{}{
+		"subscription_item": "si_123",
+		"quantity":          10,
+		"timestamp":         "2030-01-01T01:00:00+01:00",
+		"idempotency_key":   "test",
+	}
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	state, diags := r.Apply(context.Background(), nil, diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if timestamp := state.Attributes["timestamp"]; timestamp != "2030-01-01T00:00:00Z" {
+		t.Errorf("expected the timestamp 2030-01-01T00:00:00Z, got %q", timestamp)
+	}
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes, got %v", diff)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripeWebhookEndpoint() *schema.Resource {
@@ -21,6 +20,7 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"idempotency_key": idempotencyKeySchema(),
 			"enabled_events": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -50,6 +50,11 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 				Default:     false,
 				Description: "Disable the webhook endpoint if set to true.",
 			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the webhook. It can be enabled or disabled.",
+			},
 			"api_version": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -67,11 +72,14 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 	}
 }
 
-func resourceStripeWebhookEndpointRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeWebhookEndpointRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 
-	webhookEndpoint, err := c.WebhookEndpoints.Get(d.Id(), nil)
+	webhookEndpoint, err := c.WebhookEndpoints.Get(d.Id(), &stripe.WebhookEndpointParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
+		if handleNotFound(err, d) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -80,13 +88,14 @@ func resourceStripeWebhookEndpointRead(_ context.Context, d *schema.ResourceData
 		d.Set("url", webhookEndpoint.URL),
 		d.Set("description", webhookEndpoint.Description),
 		d.Set("disabled", webhookEndpoint.Status != "enabled"),
+		d.Set("status", webhookEndpoint.Status),
 		d.Set("api_version", webhookEndpoint.APIVersion),
-		d.Set("metadata", webhookEndpoint.Metadata),
+		d.Set("metadata", m.(*Config).withoutDefaultMetadata(webhookEndpoint.Metadata, ExtractMap(d, "metadata"))),
 	)
 }
 
 func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.WebhookEndpointParams{
 		URL:           stripe.String(ExtractString(d, "url")),
 		EnabledEvents: stripe.StringSlice(ExtractStringSlice(d, "enabled_events")),
@@ -97,12 +106,10 @@ func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.Resource
 	if APIVersion, set := d.GetOk("api_version"); set {
 		params.APIVersion = stripe.String(ToString(APIVersion))
 	}
-	if meta, set := d.GetOk("metadata"); set {
-		for k, v := range ToMap(meta) {
-			params.AddMetadata(k, ToString(v))
-		}
-	}
+	m.(*Config).expandMetadata(d, "metadata", &params.Params)
 
+	setIdempotencyKey(d, &params.Params)
+	params.Context = ctx
 	webhookEndpoint, err := c.WebhookEndpoints.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -120,7 +127,7 @@ func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*Config).API
 	params := &stripe.WebhookEndpointParams{}
 
 	if d.HasChange("enabled_events") {
@@ -136,13 +143,10 @@ func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.Resource
 		params.Disabled = stripe.Bool(ExtractBool(d, "disabled"))
 	}
 	if d.HasChange("metadata") {
-		params.Metadata = nil
-		metadata := ExtractMap(d, "metadata")
-		for k, v := range metadata {
-			params.AddMetadata(k, ToString(v))
-		}
+		m.(*Config).expandMetadata(d, "metadata", &params.Params)
 	}
 
+	params.Context = ctx
 	_, err := c.WebhookEndpoints.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -151,10 +155,10 @@ func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.Resource
 	return resourceStripeWebhookEndpointRead(ctx, d, m)
 }
 
-func resourceStripeWebhookEndpointDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeWebhookEndpointDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Config).API
 
-	_, err := c.WebhookEndpoints.Del(d.Id(), nil)
+	_, err := c.WebhookEndpoints.Del(d.Id(), &stripe.WebhookEndpointParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
 		return diag.FromErr(err)
 	}
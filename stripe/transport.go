@@ -0,0 +1,174 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultHTTPTimeout mirrors the timeout of the HTTP client stripe-go uses when none is configured.
+const defaultHTTPTimeout = 80 * time.Second
+
+// apiVersionTransport overrides the Stripe-Version header stripe-go attaches to every request,
+// pinning the provider to the API version configured by the user. Betas a request opts into,
+// like the Orders API beta, are listed after the version and kept.
+type apiVersionTransport struct {
+	version string
+	next    http.RoundTripper
+}
+
+func (t *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	version := t.version
+	if _, betas, found := strings.Cut(req.Header.Get("Stripe-Version"), ";"); found {
+		version += ";" + betas
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Stripe-Version", version)
+	return t.next.RoundTrip(req)
+}
+
+// stripeAccountTransport attaches the Stripe-Account header to requests, so a platform API key
+// operates on the connected account configured by the user. A header set by the request itself wins.
+type stripeAccountTransport struct {
+	account string
+	next    http.RoundTripper
+}
+
+func (t *stripeAccountTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Stripe-Account") != "" {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Stripe-Account", t.account)
+	return t.next.RoundTrip(req)
+}
+
+const (
+	rateLimitInitialBackoff = 500 * time.Millisecond
+	rateLimitMaxBackoff     = 30 * time.Second
+)
+
+// rateLimitTransport retries requests Stripe rejected with 429 Too Many Requests.
+// It waits for the duration of the Retry-After header if present and falls back to a capped
+// exponential backoff otherwise. Stripe doesn't process rate limited requests, and the writes stripe-go
+// sends carry an Idempotency-Key every attempt repeats, so a retried create can't produce a duplicate.
+// A request isn't retried when Stripe answers Stripe-Should-Retry: false, or when the wait would
+// outlast the deadline of the request, the 429 is returned right away instead.
+type rateLimitTransport struct {
+	maxRetries int
+	next       http.RoundTripper
+	// sleep waits between the attempts, it's replaced by the tests
+	sleep func(ctx context.Context, wait time.Duration) error
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		wait := rateLimitBackoff(resp.Header.Get("Retry-After"), attempt)
+		if !canRetryRateLimited(req, resp, wait) {
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		log.Printf("[DEBUG] Stripe rate limit hit on %s %s, retrying in %s", req.Method, req.URL.Path, wait)
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *rateLimitTransport) wait(ctx context.Context, wait time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, wait)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// canRetryRateLimited reports whether the rate limited request is worth another attempt after the wait.
+func canRetryRateLimited(req *http.Request, resp *http.Response, wait time.Duration) bool {
+	if resp.Header.Get("Stripe-Should-Retry") == "false" {
+		return false
+	}
+	if deadline, set := req.Context().Deadline(); set && time.Now().Add(wait).After(deadline) {
+		return false
+	}
+	return true
+}
+
+func rateLimitBackoff(retryAfter string, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		if wait := time.Duration(seconds) * time.Second; wait < rateLimitMaxBackoff {
+			return wait
+		}
+		return rateLimitMaxBackoff
+	}
+
+	wait := rateLimitInitialBackoff << attempt
+	if wait <= 0 || wait > rateLimitMaxBackoff {
+		return rateLimitMaxBackoff
+	}
+	return wait
+}
+
+// timeoutTransport bounds every single attempt of a request, including reading the response body.
+// Unlike http.Client.Timeout it doesn't cover the waits of rateLimitTransport between the attempts.
+type timeoutTransport struct {
+	timeout time.Duration
+	next    http.RoundTripper
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the context of an attempt once its response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,230 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// roundTripFunc lets a function stand in for the next transport of the chain.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAPIVersionTransport(t *testing.T) {
+	cases := []struct {
+		name     string
+		sent     string
+		expected string
+	}{
+		{"version only", "2020-08-27", "2022-11-15"},
+		{"beta kept", "2020-08-27; orders_beta=v4", "2022-11-15; orders_beta=v4"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var version string
+			transport := &apiVersionTransport{
+				version: "2022-11-15",
+				next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					version = req.Header.Get("Stripe-Version")
+					return httptest.NewRecorder().Result(), nil
+				}),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "https://api.stripe.com/v1/orders", nil)
+			req.Header.Set("Stripe-Version", tc.sent)
+			if _, err := transport.RoundTrip(req); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if version != tc.expected {
+				t.Errorf("expected Stripe-Version %q, got %q", tc.expected, version)
+			}
+		})
+	}
+}
+
+// testRateLimitServer answers with the given statuses in turn, the last one repeats.
+// A status is followed by the value of the Retry-After header, if any.
+type testRateLimitServer struct {
+	mu        sync.Mutex
+	responses [][2]string
+	requests  []*http.Request
+	bodies    []string
+}
+
+func (s *testRateLimitServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	response := s.responses[len(s.responses)-1]
+	if len(s.requests) < len(s.responses) {
+		response = s.responses[len(s.requests)]
+	}
+	s.requests = append(s.requests, r)
+	s.bodies = append(s.bodies, string(body))
+
+	if response[1] != "" {
+		w.Header().Set("Retry-After", response[1])
+	}
+	if response[0] == "429 no retry" {
+		w.Header().Set("Stripe-Should-Retry", "false")
+		response[0] = "429"
+	}
+	if response[0] == "429" {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxRetries int
+		responses  [][2]string
+		deadline   time.Duration
+		status     int
+		waits      []time.Duration
+	}{
+		{"no rate limit", 3, [][2]string{{"200"}}, 0, http.StatusOK, nil},
+		{"retry after", 3, [][2]string{{"429", "7"}, {"200"}}, 0, http.StatusOK,
+			[]time.Duration{7 * time.Second}},
+		{"exponential backoff", 5, [][2]string{{"429"}, {"429"}, {"429"}, {"200"}}, 0, http.StatusOK,
+			[]time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second}},
+		{"retry after capped", 3, [][2]string{{"429", "120"}, {"200"}}, 0, http.StatusOK,
+			[]time.Duration{rateLimitMaxBackoff}},
+		{"max_retries exhausted", 2, [][2]string{{"429", "1"}}, 0, http.StatusTooManyRequests,
+			[]time.Duration{time.Second, time.Second}},
+		{"stripe says no retry", 3, [][2]string{{"429 no retry", "1"}}, 0, http.StatusTooManyRequests, nil},
+		{"wait within the deadline", 3, [][2]string{{"429", "7"}, {"200"}}, time.Minute, http.StatusOK,
+			[]time.Duration{7 * time.Second}},
+		{"wait past a short deadline", 3, [][2]string{{"429", "7"}, {"200"}}, time.Second,
+			http.StatusTooManyRequests, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &testRateLimitServer{responses: tc.responses}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			var waits []time.Duration
+			client := &http.Client{Transport: &rateLimitTransport{
+				maxRetries: tc.maxRetries,
+				next:       http.DefaultTransport,
+				sleep: func(_ context.Context, wait time.Duration) error {
+					waits = append(waits, wait)
+					return nil
+				},
+			}}
+
+			ctx := context.Background()
+			if tc.deadline > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.deadline)
+				defer cancel()
+			}
+			req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/coupons",
+				strings.NewReader("percent_off=25"))
+			req.Header.Set("Idempotency-Key", "key_123")
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+			if !reflect.DeepEqual(waits, tc.waits) {
+				t.Errorf("expected waits %v, got %v", tc.waits, waits)
+			}
+			if len(handler.requests) != len(tc.waits)+1 {
+				t.Errorf("expected %d requests, got %d", len(tc.waits)+1, len(handler.requests))
+			}
+			// every attempt repeats the body and the idempotency key of the request
+			for i, r := range handler.requests {
+				if key := r.Header.Get("Idempotency-Key"); key != "key_123" {
+					t.Errorf("attempt %d sent Idempotency-Key %q", i, key)
+				}
+				if handler.bodies[i] != "percent_off=25" {
+					t.Errorf("attempt %d sent body %q", i, handler.bodies[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	cases := []struct {
+		retryAfter string
+		attempt    int
+		expected   time.Duration
+	}{
+		{"", 0, rateLimitInitialBackoff},
+		{"", 3, 8 * rateLimitInitialBackoff},
+		{"", 6, rateLimitMaxBackoff},
+		{"", 100, rateLimitMaxBackoff},
+		{"0", 2, 0},
+		{"12", 0, 12 * time.Second},
+		{"3600", 0, rateLimitMaxBackoff},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 1, 2 * rateLimitInitialBackoff},
+	}
+	for _, tc := range cases {
+		if wait := rateLimitBackoff(tc.retryAfter, tc.attempt); wait != tc.expected {
+			t.Errorf("rateLimitBackoff(%q, %d): expected %s, got %s", tc.retryAfter, tc.attempt, tc.expected, wait)
+		}
+	}
+}
+
+// TestTimeoutTransport checks that the timeout bounds every attempt and not the waits between them.
+func TestTimeoutTransport(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		attempt := requests
+		mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/slow":
+			time.Sleep(300 * time.Millisecond)
+		case attempt == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitTransport{
+		maxRetries: 1,
+		next:       &timeoutTransport{timeout: 100 * time.Millisecond, next: http.DefaultTransport},
+		sleep: func(ctx context.Context, _ time.Duration) error {
+			// waiting longer than the timeout must not fail the next attempt
+			time.Sleep(200 * time.Millisecond)
+			return ctx.Err()
+		},
+	}}
+
+	resp, err := client.Get(server.URL + "/retried")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	_, err = client.Get(server.URL + "/slow")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the slow attempt to time out, got %v", err)
+	}
+}
@@ -1,10 +1,25 @@
 package stripe
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v72"
 )
 
+// defaultResourceTimeout bounds every operation of resources declaring timeouts, the SDK passes it
+// down as the deadline of the request context, which the resources hand to stripe-go.
+const defaultResourceTimeout = 5 * time.Minute
+
 func ExtractString(d *schema.ResourceData, key string) string {
 	return ToString(d.Get(key))
 }
@@ -14,7 +29,10 @@ func ToString(value interface{}) string {
 	case string:
 		return value.(string)
 	case *string:
-		return *(value.(*string))
+		if v := value.(*string); v != nil {
+			return *v
+		}
+		return ""
 	default:
 		return ""
 	}
@@ -33,11 +51,17 @@ func ToInt(value interface{}) int {
 	case int:
 		return value.(int)
 	case *int:
-		return *(value.(*int))
+		if v := value.(*int); v != nil {
+			return *v
+		}
+		return 0
 	case int64:
 		return int(value.(int64))
 	case *int64:
-		return int(*(value.(*int64)))
+		if v := value.(*int64); v != nil {
+			return int(*v)
+		}
+		return 0
 	default:
 		return 0
 	}
@@ -48,11 +72,17 @@ func ToInt64(value interface{}) int64 {
 	case int:
 		return int64(value.(int))
 	case *int:
-		return int64(*(value.(*int)))
+		if v := value.(*int); v != nil {
+			return int64(*v)
+		}
+		return 0
 	case int64:
 		return value.(int64)
 	case *int64:
-		return *(value.(*int64))
+		if v := value.(*int64); v != nil {
+			return *v
+		}
+		return 0
 	default:
 		return 0
 	}
@@ -63,16 +93,30 @@ func ToFloat64(value interface{}) float64 {
 	case float32:
 		return float64(value.(float32))
 	case *float32:
-		return float64(*(value.(*float32)))
+		if v := value.(*float32); v != nil {
+			return float64(*v)
+		}
+		return 0
 	case float64:
 		return value.(float64)
 	case *float64:
-		return *(value.(*float64))
+		if v := value.(*float64); v != nil {
+			return *v
+		}
+		return 0
 	default:
 		return 0
 	}
 }
 
+// ToRFC3339 formats the Unix timestamp in UTC, the state mustn't depend on the time zone of the host.
+func ToRFC3339(timestamp int64) string {
+	if timestamp == 0 {
+		return ""
+	}
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
+
 //func ExtractSlice(d *schema.ResourceData, key string) []interface{} {
 //	return ToSlice(d.Get(key))
 //}
@@ -103,6 +147,32 @@ func ToStringSlice(value interface{}) []string {
 	return stringSlice
 }
 
+func ToFloat64Slice(value interface{}) []float64 {
+	slice, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	floatSlice := make([]float64, len(slice))
+	for i := range slice {
+		floatSlice[i] = ToFloat64(slice[i])
+	}
+	return floatSlice
+}
+
+func ToIntSlice(value interface{}) []int64 {
+	slice, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	intSlice := make([]int64, len(slice))
+	for i := range slice {
+		intSlice[i] = ToInt64(slice[i])
+	}
+	return intSlice
+}
+
 func ExtractBool(d *schema.ResourceData, key string) bool {
 	return ToBool(d.Get(key))
 }
@@ -112,7 +182,10 @@ func ToBool(value interface{}) bool {
 	case bool:
 		return value.(bool)
 	case *bool:
-		return *(value.(*bool))
+		if b := value.(*bool); b != nil {
+			return *b
+		}
+		return false
 	default:
 		return false
 	}
@@ -129,12 +202,24 @@ func ToMap(value interface{}) map[string]interface{} {
 	case []interface{}:
 		sl := value.([]interface{})
 		if len(sl) > 0 {
-			return sl[0].(map[string]interface{})
+			if m, ok := sl[0].(map[string]interface{}); ok {
+				return m
+			}
 		}
 	}
 	return map[string]interface{}{}
 }
 
+// attributeErrorf is diag.Errorf for errors caused by a single attribute,
+// terraform points at the attribute in the configuration when reporting them.
+func attributeErrorf(attribute string, format string, a ...interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf(format, a...),
+		AttributePath: cty.GetAttrPath(attribute),
+	}}
+}
+
 func CallSet(err ...error) (d diag.Diagnostics) {
 	for _, e := range err {
 		if e != nil {
@@ -146,3 +231,198 @@ func CallSet(err ...error) (d diag.Diagnostics) {
 	}
 	return d
 }
+
+// rawCall sends a request to an endpoint stripe-go has no client for. The services share one backend
+// and API key, so any of them can carry the call; v receives the decoded response.
+func rawCall(m interface{}, method, path string, params stripe.ParamsContainer, v stripe.LastResponseSetter) error {
+	c := m.(*Config).API
+	return c.Customers.B.Call(method, path, c.Customers.Key, params, v)
+}
+
+// handleNotFound removes the resource from the state when the error reports that the object
+// no longer exists in Stripe, e.g. because it was deleted from the dashboard.
+func handleNotFound(err error, d *schema.ResourceData) bool {
+	if isNotFound(err) {
+		d.SetId("")
+		return true
+	}
+	return false
+}
+
+// isNotFound reports whether the error means that the requested object doesn't exist in Stripe.
+func isNotFound(err error) bool {
+	var stripeErr *stripe.Error
+	return errors.As(err, &stripeErr) &&
+		(stripeErr.HTTPStatusCode == http.StatusNotFound || stripeErr.Code == stripe.ErrorCodeResourceMissing)
+}
+
+// idempotencyKeySchema is the idempotency_key argument of the resources creating Stripe objects.
+// A different key means a different create, so changing it replaces the object.
+func idempotencyKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description: "Idempotency key sent with the request creating the object. " +
+			"Stripe answers a create repeating a key from the last 24 hours with the object created first instead of a duplicate. " +
+			"A generated key only covers the retries within one apply, set it to make rerunning a failed apply safe. " +
+			"A set key must be changed along with any change replacing the object, Stripe would return the replaced object otherwise. " +
+			"Changing it replaces the object.",
+	}
+}
+
+// setIdempotencyKey attaches the idempotency key to a create request. Without a configured key one is
+// generated and kept in the state, so every retry of the request, by stripe-go or the rate limit retries,
+// sends the same key. A create that fails records no state, the next apply generates a new key; only a
+// configured key carries over, and it's sent again as is when the object is replaced.
+func setIdempotencyKey(d *schema.ResourceData, params *stripe.Params) {
+	key := ExtractString(d, "idempotency_key")
+	if key == "" {
+		key = stripe.NewIdempotencyKey()
+		if err := d.Set("idempotency_key", key); err != nil {
+			log.Printf("[WARN] Can't record the idempotency key %s: %s", key, err)
+		}
+	}
+	params.SetIdempotencyKey(key)
+}
+
+// iso4217Currencies lists the active ISO 4217 currency codes, in the lowercase form Stripe uses.
+var iso4217Currencies = map[string]struct{}{
+	"aed": {}, "afn": {}, "all": {}, "amd": {}, "ang": {}, "aoa": {}, "ars": {}, "aud": {}, "awg": {}, "azn": {},
+	"bam": {}, "bbd": {}, "bdt": {}, "bgn": {}, "bhd": {}, "bif": {}, "bmd": {}, "bnd": {}, "bob": {}, "brl": {},
+	"bsd": {}, "btn": {}, "bwp": {}, "byn": {}, "bzd": {}, "cad": {}, "cdf": {}, "chf": {}, "clp": {}, "cny": {},
+	"cop": {}, "crc": {}, "cuc": {}, "cup": {}, "cve": {}, "czk": {}, "djf": {}, "dkk": {}, "dop": {}, "dzd": {},
+	"egp": {}, "ern": {}, "etb": {}, "eur": {}, "fjd": {}, "fkp": {}, "gbp": {}, "gel": {}, "ghs": {}, "gip": {},
+	"gmd": {}, "gnf": {}, "gtq": {}, "gyd": {}, "hkd": {}, "hnl": {}, "hrk": {}, "htg": {}, "huf": {}, "idr": {},
+	"ils": {}, "inr": {}, "iqd": {}, "irr": {}, "isk": {}, "jmd": {}, "jod": {}, "jpy": {}, "kes": {}, "kgs": {},
+	"khr": {}, "kmf": {}, "kpw": {}, "krw": {}, "kwd": {}, "kyd": {}, "kzt": {}, "lak": {}, "lbp": {}, "lkr": {},
+	"lrd": {}, "lsl": {}, "lyd": {}, "mad": {}, "mdl": {}, "mga": {}, "mkd": {}, "mmk": {}, "mnt": {}, "mop": {},
+	"mru": {}, "mur": {}, "mvr": {}, "mwk": {}, "mxn": {}, "myr": {}, "mzn": {}, "nad": {}, "ngn": {}, "nio": {},
+	"nok": {}, "npr": {}, "nzd": {}, "omr": {}, "pab": {}, "pen": {}, "pgk": {}, "php": {}, "pkr": {}, "pln": {},
+	"pyg": {}, "qar": {}, "ron": {}, "rsd": {}, "rub": {}, "rwf": {}, "sar": {}, "sbd": {}, "scr": {}, "sdg": {},
+	"sek": {}, "sgd": {}, "shp": {}, "sle": {}, "sll": {}, "sos": {}, "srd": {}, "ssp": {}, "stn": {}, "svc": {},
+	"syp": {}, "szl": {}, "thb": {}, "tjs": {}, "tmt": {}, "tnd": {}, "top": {}, "try": {}, "ttd": {}, "twd": {},
+	"tzs": {}, "uah": {}, "ugx": {}, "usd": {}, "uyu": {}, "uzs": {}, "ves": {}, "vnd": {}, "vuv": {}, "wst": {},
+	"xaf": {}, "xcd": {}, "xof": {}, "xpf": {}, "yer": {}, "zar": {}, "zmw": {}, "zwl": {},
+}
+
+// validateCurrency checks for a lowercase three-letter ISO 4217 code, which is how Stripe returns currencies.
+func validateCurrency(v interface{}, k string) (warnings []string, errs []error) {
+	currency, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	lower := strings.ToLower(currency)
+	if _, known := iso4217Currencies[lower]; !known {
+		return nil, []error{fmt.Errorf("%s: %q is not a three-letter ISO 4217 currency code", k, currency)}
+	}
+	if lower != currency {
+		return nil, []error{fmt.Errorf("%s: currency codes have to be lowercase, use %q instead of %q", k, lower, currency)}
+	}
+	return nil, nil
+}
+
+// validateRFC3339 rejects timestamps Stripe can't be sent, every date argument is passed in as an RFC3339 string.
+// Empty values are accepted, the date arguments are optional.
+func validateRFC3339(v interface{}, k string) (warnings []string, errs []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return nil, []error{fmt.Errorf("%s: can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", k, value)}
+	}
+	return nil, nil
+}
+
+// parseTimestamp reads a date given either in the RFC3339 format or as Unix epoch seconds.
+func parseTimestamp(value string) (int64, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant or Unix epoch seconds", value)
+	}
+	return seconds, nil
+}
+
+// validateTimestamp is validateRFC3339 for the date arguments also accepting Unix epoch seconds.
+func validateTimestamp(v interface{}, k string) (warnings []string, errs []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+	if _, err := parseTimestamp(value); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// suppressEquivalentTimestamps hides the diff between a date configured as Unix epoch seconds
+// and the same date read back in the RFC3339 format.
+func suppressEquivalentTimestamps(_, old, new string, _ *schema.ResourceData) bool {
+	oldTimestamp, err := parseTimestamp(old)
+	if err != nil {
+		return false
+	}
+	newTimestamp, err := parseTimestamp(new)
+	return err == nil && oldTimestamp == newTimestamp
+}
+
+// diagFromStripeErr works like diag.FromErr but keeps the request ID of failed Stripe API calls,
+// which Stripe support needs to look up the request. Errors caused by a parameter point at the argument
+// of the same name, the arguments of the resources follow the Stripe API names.
+func diagFromStripeErr(err error) diag.Diagnostics {
+	var stripeErr *stripe.Error
+	if !errors.As(err, &stripeErr) {
+		return diag.FromErr(err)
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+	}
+	if stripeErr.RequestID != "" {
+		log.Printf("[ERROR] stripe request %s failed: %s (HTTP status %d)",
+			stripeErr.RequestID, stripeErr.Msg, stripeErr.HTTPStatusCode)
+		diagnostic.Detail = fmt.Sprintf("Stripe request ID: %s, HTTP status: %d", stripeErr.RequestID, stripeErr.HTTPStatusCode)
+	}
+	if stripeErr.Param != "" {
+		// nested parameters like currency_options[eur][amount_off] point at the top level argument
+		attribute, _, _ := strings.Cut(stripeErr.Param, "[")
+		diagnostic.AttributePath = cty.GetAttrPath(attribute)
+	}
+	return diag.Diagnostics{diagnostic}
+}
+
+// keepConfiguredOrder returns the configured list when it holds the same elements as the values read from
+// Stripe, so that the API returning them in another order doesn't show up as a diff.
+func keepConfiguredOrder(values []string, configured []string) []string {
+	if len(values) != len(configured) {
+		return values
+	}
+
+	counts := make(map[string]int, len(values))
+	for _, v := range values {
+		counts[v]++
+	}
+	for _, v := range configured {
+		if counts[v] == 0 {
+			return values
+		}
+		counts[v]--
+	}
+	return configured
+}
@@ -0,0 +1,391 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// TestCallSet checks that a failed d.Set turns into a diagnostic, as in the coupon create,
+// instead of the attribute being left out of the state silently.
+func TestCallSet(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, map[string]interface{}{"percent_off": 25})
+	diags := CallSet(
+		d.Set("valid", true),
+		d.Set("times_redeemed", "many"),
+	)
+	if len(diags) != 1 || !diags.HasError() {
+		t.Fatalf("expected a single error, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Summary, "times_redeemed") {
+		t.Errorf("expected the error to name times_redeemed, got %q", diags[0].Summary)
+	}
+	if !ExtractBool(d, "valid") {
+		t.Errorf("expected the other attributes to be set")
+	}
+}
+
+func TestDiagFromStripeErr(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		path   cty.Path
+		detail string
+	}{
+		{"other error", errors.New("connection refused"), nil, ""},
+		{"without param", &stripe.Error{Msg: "Invalid API Key", RequestID: "req_123", HTTPStatusCode: 401}, nil,
+			"Stripe request ID: req_123, HTTP status: 401"},
+		{"param", &stripe.Error{Msg: "Invalid integer", Param: "amount_off", HTTPStatusCode: 400},
+			cty.GetAttrPath("amount_off"), ""},
+		{"nested param", &stripe.Error{Msg: "Invalid integer", Param: "currency_options[eur][amount_off]"},
+			cty.GetAttrPath("currency_options"), ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := diagFromStripeErr(tc.err)
+			if len(diags) != 1 || !diags.HasError() {
+				t.Fatalf("expected a single error, got %v", diags)
+			}
+			if !diags[0].AttributePath.Equals(tc.path) {
+				t.Errorf("expected attribute path %#v, got %#v", tc.path, diags[0].AttributePath)
+			}
+			if diags[0].Detail != tc.detail {
+				t.Errorf("expected detail %q, got %q", tc.detail, diags[0].Detail)
+			}
+		})
+	}
+}
+
+// TestSetIdempotencyKey creates a coupon against a server which fails the first attempts, once with a conflict
+// stripe-go retries and once with a rate limit rateLimitTransport retries. Every attempt has to send the same key.
+func TestSetIdempotencyKey(t *testing.T) {
+	cases := []struct {
+		name       string
+		configured string
+	}{
+		{"generated", ""},
+		{"configured", "coupon-launch-2030"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var keys []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				if r.Method != http.MethodPost {
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25}`)
+					return
+				}
+
+				keys = append(keys, r.Header.Get("Idempotency-Key"))
+				w.Header().Set("Content-Type", "application/json")
+				switch len(keys) {
+				case 1:
+					w.WriteHeader(http.StatusConflict)
+					fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "Conflict"}}`)
+				case 2:
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "code": "rate_limit"}}`)
+				default:
+					fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25}`)
+				}
+			}))
+			defer server.Close()
+
+			backend := stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+				URL:               stripe.String(server.URL),
+				MaxNetworkRetries: stripe.Int64(1),
+				HTTPClient: &http.Client{Transport: &rateLimitTransport{
+					maxRetries: 1,
+					next:       http.DefaultTransport,
+					sleep:      func(context.Context, time.Duration) error { return nil },
+				}},
+			})
+			api := client.New("sk_test_123", &stripe.Backends{API: backend, Connect: backend, Uploads: backend})
+
+			raw := map[string]interface{}{"percent_off": 25}
+			if tc.configured != "" {
+				raw["idempotency_key"] = tc.configured
+			}
+			d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, raw)
+			if diags := resourceStripeCouponCreate(context.Background(), d, &Config{API: api}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if len(keys) != 3 {
+				t.Fatalf("expected 3 attempts, got %d", len(keys))
+			}
+			key := ExtractString(d, "idempotency_key")
+			if key == "" || (tc.configured != "" && key != tc.configured) {
+				t.Errorf("unexpected idempotency_key %q in the state", key)
+			}
+			for i, sent := range keys {
+				if sent != key {
+					t.Errorf("attempt %d sent Idempotency-Key %q, expected %q", i, sent, key)
+				}
+			}
+		})
+	}
+}
+
+// TestSetIdempotencyKey_separateCreates covers creates in separate applies, e.g. a rerun after a failed
+// create or a replacement: only a configured key is sent again.
+func TestSetIdempotencyKey_separateCreates(t *testing.T) {
+	cases := []struct {
+		name       string
+		configured string
+		same       bool
+	}{
+		{"generated", "", false},
+		{"configured", "coupon-launch-2030", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var keys []string
+			api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				if r.Method == http.MethodPost {
+					keys = append(keys, r.Header.Get("Idempotency-Key"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"id": "test", "object": "coupon", "duration": "once", "percent_off": 25}`)
+			})
+
+			raw := map[string]interface{}{"percent_off": 25}
+			if tc.configured != "" {
+				raw["idempotency_key"] = tc.configured
+			}
+			for i := 0; i < 2; i++ {
+				d := schema.TestResourceDataRaw(t, resourceStripeCoupon().Schema, raw)
+				if diags := resourceStripeCouponCreate(context.Background(), d, &Config{API: api}); diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+			}
+
+			if len(keys) != 2 {
+				t.Fatalf("expected 2 creates, got %d", len(keys))
+			}
+			if keys[0] == "" || (keys[0] == keys[1]) != tc.same {
+				t.Errorf("unexpected Idempotency-Key %q and %q", keys[0], keys[1])
+			}
+		})
+	}
+}
+
+// TestToNumberSlices converts lists the way d.Get returns them, a value that isn't a list converts to nil.
+func TestToNumberSlices(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  interface{}
+		floats []float64
+		ints   []int64
+	}{
+		{"not a list", "1,2", nil, nil},
+		{"empty", []interface{}{}, []float64{}, []int64{}},
+		{"floats", []interface{}{2.5, 10.0}, []float64{2.5, 10}, []int64{0, 0}},
+		{"ints", []interface{}{5, int64(10)}, []float64{0, 0}, []int64{5, 10}},
+		{"pointers", []interface{}{stripe.Float64(2.5), stripe.Int64(10)}, []float64{2.5, 0}, []int64{0, 10}},
+		{"nil pointers", []interface{}{(*float64)(nil), (*int64)(nil)}, []float64{0, 0}, []int64{0, 0}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if floats := ToFloat64Slice(tc.value); !reflect.DeepEqual(floats, tc.floats) {
+				t.Errorf("expected floats %#v, got %#v", tc.floats, floats)
+			}
+			if ints := ToIntSlice(tc.value); !reflect.DeepEqual(ints, tc.ints) {
+				t.Errorf("expected ints %#v, got %#v", tc.ints, ints)
+			}
+		})
+	}
+}
+
+func TestToBool(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{"true", true, true},
+		{"false", false, false},
+		{"pointer", stripe.Bool(true), true},
+		{"nil pointer", (*bool)(nil), false},
+		{"nil", nil, false},
+		{"not a bool", "true", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if value := ToBool(tc.value); value != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, value)
+			}
+		})
+	}
+}
+
+// TestRawCall checks that a call to an endpoint without a stripe-go client reaches the provider's backend.
+func TestRawCall(t *testing.T) {
+	var request string
+	api := testStripeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		request = fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, r.PostForm)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "feat_123", "object": "entitlements.feature", "lookup_key": "seats"}`)
+	})
+
+	params := &entitlementsFeatureParams{LookupKey: stripe.String("seats")}
+	feature := &entitlementsFeature{}
+	if err := rawCall(&Config{API: api}, http.MethodPost, "/v1/entitlements/features", params, feature); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "POST /v1/entitlements/features map[lookup_key:[seats]]"; request != expected {
+		t.Errorf("expected the request %q, got %q", expected, request)
+	}
+	if feature.ID != "feat_123" {
+		t.Errorf("expected the feature feat_123 decoded, got %q", feature.ID)
+	}
+}
+
+func TestKeepConfiguredOrder(t *testing.T) {
+	cases := []struct {
+		name       string
+		values     []string
+		configured []string
+		expected   []string
+	}{
+		{"reordered", []string{"prod_b", "prod_a"}, []string{"prod_a", "prod_b"}, []string{"prod_a", "prod_b"}},
+		{"duplicates", []string{"prod_b", "prod_a", "prod_b"}, []string{"prod_b", "prod_b", "prod_a"},
+			[]string{"prod_b", "prod_b", "prod_a"}},
+		{"removed", []string{"prod_b"}, []string{"prod_a", "prod_b"}, []string{"prod_b"}},
+		{"replaced", []string{"prod_b", "prod_c"}, []string{"prod_a", "prod_b"}, []string{"prod_b", "prod_c"}},
+		{"not configured", []string{"prod_b", "prod_a"}, nil, []string{"prod_b", "prod_a"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if values := keepConfiguredOrder(tc.values, tc.configured); !reflect.DeepEqual(values, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, values)
+			}
+		})
+	}
+}
+
+// TestValidateRFC3339 covers the helper shared by the date arguments, e.g. expires_at of a promotion code.
+func TestValidateRFC3339(t *testing.T) {
+	cases := []struct {
+		value string
+		err   bool
+	}{
+		{"", false},
+		{"2030-01-01T00:00:00Z", false},
+		{"2030-01-01T01:00:00+01:00", false},
+		{"2030-01-01", true},
+		{"1893456000", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			_, errs := validateRFC3339(tc.value, "expires_at")
+			if (len(errs) > 0) != tc.err {
+				t.Errorf("expected an error %t, got %v", tc.err, errs)
+			}
+
+			diags := resourceStripePromotionCode().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"coupon":     "test",
+				"expires_at": tc.value,
+			}))
+			if diags.HasError() != tc.err {
+				t.Errorf("expected the promotion code to fail validation %t, got %v", tc.err, diags)
+			}
+		})
+	}
+}
+
+// TestValidateCurrency covers the helper shared by the currency arguments, e.g. currency of a coupon.
+func TestValidateCurrency(t *testing.T) {
+	cases := []struct {
+		value string
+		err   bool
+	}{
+		{"usd", false},
+		{"eur", false},
+		{"us", true},
+		{"usdt", true},
+		{"xyz", true},
+		{"USD", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			_, errs := validateCurrency(tc.value, "currency")
+			if (len(errs) > 0) != tc.err {
+				t.Errorf("expected an error %t, got %v", tc.err, errs)
+			}
+
+			diags := resourceStripeCoupon().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"duration":   "once",
+				"amount_off": 500,
+				"currency":   tc.value,
+			}))
+			if diags.HasError() != tc.err {
+				t.Errorf("expected the coupon to fail validation %t, got %v", tc.err, diags)
+			}
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected int64
+		err      bool
+	}{
+		{"2030-01-01T00:00:00Z", 1893456000, false},
+		{"2030-01-01T01:00:00+01:00", 1893456000, false},
+		{"1893456000", 1893456000, false},
+		{"2030-01-01", 0, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			timestamp, err := parseTimestamp(tc.value)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected an error %t, got %v", tc.err, err)
+			}
+			if timestamp != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, timestamp)
+			}
+			if !tc.err && !suppressEquivalentTimestamps("redeem_by", "2030-01-01T00:00:00Z", tc.value, nil) {
+				t.Errorf("expected %q to be the same time as 2030-01-01T00:00:00Z", tc.value)
+			}
+		})
+	}
+}
+
+// TestToRFC3339 formats timestamps on a host which isn't in UTC.
+func TestToRFC3339(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("CET", 3600)
+	t.Cleanup(func() { time.Local = local })
+
+	if formatted := ToRFC3339(1893456000); formatted != "2030-01-01T00:00:00Z" {
+		t.Errorf("expected 2030-01-01T00:00:00Z, got %q", formatted)
+	}
+	if formatted := ToRFC3339(0); formatted != "" {
+		t.Errorf("expected no time for 0, got %q", formatted)
+	}
+}